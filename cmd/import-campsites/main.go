@@ -0,0 +1,143 @@
+package main
+
+import (
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/storage"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Kamp alanı kataloğunu JSON dizisinden veya CSV dosyasından doldurur.
+// CSV başlığı: name,address,latitude,longitude,site_url,amenities,season
+// (amenities ";" ile ayrılır; id, description, source ve verified sütunları isteğe bağlıdır).
+//
+//	go run ./cmd/import-campsites -db catalogue.db -source tkd -verified campsites.csv
+func main() {
+	dsn := flag.String("db", "catalogue.db", "catalogue SQLite file")
+	source := flag.String("source", "", "source label for rows without one")
+	verified := flag.Bool("verified", false, "mark all imported rows as verified")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatalf("usage: import-campsites [-db catalogue.db] [-source name] [-verified] file.json|file.csv ...")
+	}
+
+	catalogue, err := storage.OpenCatalogue(*dsn)
+	if err != nil {
+		log.Fatalf("❌ Catalogue açılamadı: %v", err)
+	}
+	defer catalogue.Close()
+
+	total := 0
+	for _, path := range flag.Args() {
+		sites, err := readFile(path)
+		if err != nil {
+			log.Fatalf("❌ %s okunamadı: %v", path, err)
+		}
+		for i := range sites {
+			if sites[i].Source == "" {
+				sites[i].Source = *source
+			}
+			sites[i].Verified = sites[i].Verified || *verified
+		}
+		written, err := catalogue.UpsertCampsites(context.Background(), sites)
+		if err != nil {
+			log.Fatalf("❌ %s içe aktarılamadı: %v", path, err)
+		}
+		log.Printf("✅ %s: %d/%d kamp alanı yazıldı", path, written, len(sites))
+		total += written
+	}
+	log.Printf("🏕️ Toplam %d kamp alanı: %s", total, *dsn)
+}
+
+func readFile(path string) ([]models.Campsite, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		var sites []models.Campsite
+		if err := json.NewDecoder(f).Decode(&sites); err != nil {
+			return nil, err
+		}
+		return sites, nil
+	case ".csv":
+		return readCSV(f)
+	default:
+		return nil, fmt.Errorf("unsupported file type: %s", filepath.Ext(path))
+	}
+}
+
+func readCSV(r io.Reader) ([]models.Campsite, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"name", "latitude", "longitude"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column: %s", required)
+		}
+	}
+
+	sites := []models.Campsite{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return sites, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		lat, err := strconv.ParseFloat(field("latitude"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: latitude: %w", line, err)
+		}
+		lon, err := strconv.ParseFloat(field("longitude"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: longitude: %w", line, err)
+		}
+		site := models.Campsite{
+			ID:          field("id"),
+			Name:        field("name"),
+			Address:     field("address"),
+			Latitude:    lat,
+			Longitude:   lon,
+			SiteURL:     field("site_url"),
+			Description: field("description"),
+			Season:      field("season"),
+			Source:      field("source"),
+			Verified:    field("verified") == "true" || field("verified") == "1",
+		}
+		for _, amenity := range strings.Split(field("amenities"), ";") {
+			if amenity = strings.TrimSpace(amenity); amenity != "" {
+				site.Amenities = append(site.Amenities, amenity)
+			}
+		}
+		sites = append(sites, site)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/osm"
+	"ai-routes-service/internal/storage"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// Kamp alanı kataloğunu indirilmiş OpenStreetMap extract'larından doldurur veya günceller.
+// .pbf (örn. Geofabrik turkey-latest.osm.pbf) ve Overpass JSON (.json) dosyaları okunur:
+//
+//	go run ./cmd/import-osm -db catalogue.db turkey-latest.osm.pbf
+//
+// Overpass sorgusu örneği (çıktısı .json olarak kaydedilir):
+//
+//	[out:json];area["ISO3166-1"="TR"]->.tr;
+//	nwr["tourism"~"^(camp_site|caravan_site)$"](area.tr);out center;
+func main() {
+	dsn := flag.String("db", "catalogue.db", "catalogue SQLite file")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatalf("usage: import-osm [-db catalogue.db] file.osm.pbf|file.json ...")
+	}
+
+	catalogue, err := storage.OpenCatalogue(*dsn)
+	if err != nil {
+		log.Fatalf("❌ Catalogue açılamadı: %v", err)
+	}
+	defer catalogue.Close()
+
+	total := 0
+	for _, path := range flag.Args() {
+		elements, err := readFile(path)
+		if err != nil {
+			log.Fatalf("❌ %s okunamadı: %v", path, err)
+		}
+
+		sites := []models.Campsite{}
+		for _, el := range elements {
+			if site, ok := osm.Campsite(el); ok {
+				sites = append(sites, site)
+			}
+		}
+		written, err := catalogue.UpsertCampsites(context.Background(), sites)
+		if err != nil {
+			log.Fatalf("❌ %s içe aktarılamadı: %v", path, err)
+		}
+		log.Printf("✅ %s: %d kamp alanı elementi, %d kayıt yazıldı (isimsiz/konumsuz: %d)", path, len(elements), written, len(elements)-len(sites))
+		total += written
+	}
+	log.Printf("🏕️ Toplam %d kamp alanı: %s", total, *dsn)
+}
+
+func readFile(path string) ([]osm.Element, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch {
+	case strings.HasSuffix(path, ".pbf"):
+		return osm.ReadPBF(f)
+	case strings.HasSuffix(path, ".json"):
+		return osm.ReadOverpass(f)
+	default:
+		return nil, fmt.Errorf("unsupported file type: %s", path)
+	}
+}
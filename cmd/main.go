@@ -1,12 +1,18 @@
 package main
 
 import (
+	"ai-routes-service/internal/geo"
 	"ai-routes-service/internal/grpc"
 	"ai-routes-service/internal/handler"
 	"ai-routes-service/internal/routes"
 	"ai-routes-service/internal/services"
+	"ai-routes-service/internal/storage"
+	"ai-routes-service/internal/utils"
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -21,6 +27,68 @@ var (
 	GoogleSearchCX  = getEnvOrDefault("GOOGLE_SEARCH_CX", "f5151badcb4504067")
 	GRPCPort        = getEnvOrDefault("GRPC_PORT", "50051")
 	HTTPPort        = getEnvOrDefault("HTTP_PORT", "9000")
+
+	// LLM sağlayıcı seçimi: "gemini", "openai" (OpenAI uyumlu herhangi bir sunucu) veya "replay"
+	LLMProvider   = getEnvOrDefault("LLM_PROVIDER", "gemini")
+	OpenAIBaseURL = getEnvOrDefault("OPENAI_BASE_URL", "http://localhost:11434/v1")
+	OpenAIApiKey  = getEnvOrDefault("OPENAI_API_KEY", "")
+	// Replay fixture dizini; LLM_RECORD=true ise seçilen sağlayıcının çağrıları buraya kaydedilir
+	LLMFixtureDir = getEnvOrDefault("LLM_FIXTURE_DIR", "testdata/replay")
+	LLMRecord     = getEnvOrDefault("LLM_RECORD", "false")
+
+	// Arama sağlayıcı seçimi: "google", "searxng" veya "fixture"
+	SearchProvider   = getEnvOrDefault("SEARCH_PROVIDER", "google")
+	SearXNGURL       = getEnvOrDefault("SEARXNG_URL", "http://localhost:8888")
+	SearchFixtureDir = getEnvOrDefault("SEARCH_FIXTURE_DIR", "testdata/search")
+
+	// Plan doğrulama ihlalleri için en fazla düzeltme turu
+	RepairRounds = getEnvOrDefault("REPAIR_ROUNDS", strconv.Itoa(services.MAX_REPAIR_ROUNDS))
+	// true ise model plan üretemediğinde sentetik plan yerine hata döner (HTTP 502 / gRPC Unavailable)
+	StrictMode = getEnvOrDefault("STRICT_MODE", "false")
+
+	// Asenkron plan job'ları (POST /api/v1/plans) için worker sayısı ve kuyruk kapasitesi
+	JobWorkers   = getEnvOrDefault("JOB_WORKERS", strconv.Itoa(services.JOB_WORKERS))
+	JobQueueSize = getEnvOrDefault("JOB_QUEUE_SIZE", strconv.Itoa(services.JOB_QUEUE_SIZE))
+
+	// Plan geçmişi: "sqlite" (STORAGE_DSN dosya yolu), "postgres" (STORAGE_DSN bağlantı URL'si) veya "none"
+	StorageDriver = getEnvOrDefault("STORAGE_DRIVER", storage.DriverSQLite)
+	StorageDSN    = getEnvOrDefault("STORAGE_DSN", "plans.db")
+
+	// Koordinat kontrolleri: bölge sınırı GeoJSON dosyası (boşsa Türkiye, "none" ise kapalı)
+	// ve koordinatın adresteki il merkezine en fazla uzaklığı
+	GeoRegionFile   = getEnvOrDefault("GEO_REGION_FILE", "")
+	GeoCityRadiusKm = getEnvOrDefault("GEO_CITY_RADIUS_KM", strconv.Itoa(services.GEO_CITY_RADIUS_KM))
+
+	// Koordinat düzeltme: "nominatim" (Nominatim uyumlu API; yerel instance veya stub) veya "none".
+	// Public sunucu kullanılıyorsa GEOCODER_USER_AGENT iletişim bilgisi içermeli.
+	Geocoder           = getEnvOrDefault("GEOCODER", "none")
+	GeocoderURL        = getEnvOrDefault("GEOCODER_URL", "https://nominatim.openstreetmap.org")
+	GeocoderUserAgent  = getEnvOrDefault("GEOCODER_USER_AGENT", "ai-routes-service")
+	GeocoderCountries  = getEnvOrDefault("GEOCODER_COUNTRY_CODES", "tr")
+	GeocodeThresholdKm = getEnvOrDefault("GEOCODE_THRESHOLD_KM", strconv.Itoa(services.GEOCODE_THRESHOLD_KM))
+
+	// Günlük mesafe sınırları (km, 0 ise o sınır kapalı); OSRM_URL boşsa kuş uçuşu mesafe kullanılır
+	DailyMinKm = getEnvOrDefault("DAILY_MIN_KM", strconv.Itoa(services.DAILY_MIN_KM))
+	DailyMaxKm = getEnvOrDefault("DAILY_MAX_KM", strconv.Itoa(services.DAILY_MAX_KM))
+	OSRMURL    = getEnvOrDefault("OSRM_URL", "")
+
+	// true ise konak sırası toplam mesafeyi kısaltacak şekilde yeniden düzenlenir
+	OptimizeRoute = getEnvOrDefault("OPTIMIZE_ROUTE", "true")
+
+	// Kamp alanı kataloğu (SQLite dosya yolu, boşsa kapalı); cmd/import-campsites ile doldurulur
+	CatalogueDSN        = getEnvOrDefault("CATALOGUE_DSN", "")
+	CatalogueCorridorKm = getEnvOrDefault("CATALOGUE_CORRIDOR_KM", strconv.Itoa(services.CATALOGUE_CORRIDOR_KM))
+	// Katalogda tarif (description) araması için embedding: "local" (API'siz), "gemini", "openai" veya "none".
+	// openai seçilirse OPENAI_BASE_URL ve OPENAI_API_KEY kullanılır
+	EmbeddingProvider = getEnvOrDefault("EMBEDDING_PROVIDER", "local")
+	EmbeddingModel    = getEnvOrDefault("EMBEDDING_MODEL", "text-embedding-004")
+
+	// Konak web sitesi kontrolü: "off" (varsayılan), "flag" (ölü linkler ihlal olarak işaretlenir) veya "drop" (plandan silinir).
+	// Yalnızca genel IP adreslerine istek atılır
+	// LINK_CHECK_TITLE=true ise sayfa başlığının kamp alanı adını içerdiği de kontrol edilir
+	LinkCheck            = getEnvOrDefault("LINK_CHECK", "off")
+	LinkCheckTitle       = getEnvOrDefault("LINK_CHECK_TITLE", "false")
+	LinkCheckConcurrency = getEnvOrDefault("LINK_CHECK_CONCURRENCY", strconv.Itoa(services.LINK_CHECK_CONCURRENCY))
 )
 
 func getEnvOrDefault(key, defaultValue string) string {
@@ -30,6 +98,58 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+func newLLMProvider() (services.Provider, error) {
+	var provider services.Provider
+	switch LLMProvider {
+	case "gemini":
+		gemini, err := services.NewGeminiProvider(ApiKey, ModelName)
+		if err != nil {
+			return nil, err
+		}
+		provider = gemini
+	case "openai":
+		provider = services.NewOpenAIProvider(OpenAIBaseURL, OpenAIApiKey, ModelName)
+	case "replay":
+		return services.NewReplayProvider(LLMFixtureDir, ModelName), nil
+	default:
+		return nil, fmt.Errorf("unknown LLM_PROVIDER: %s", LLMProvider)
+	}
+
+	if LLMRecord == "true" {
+		log.Printf("📼 LLM istekleri kaydediliyor: %s", LLMFixtureDir)
+		return services.NewRecordingProvider(provider, LLMFixtureDir), nil
+	}
+	return provider, nil
+}
+
+func newEmbedder() (services.Embedder, error) {
+	switch EmbeddingProvider {
+	case "none":
+		return nil, nil
+	case "local":
+		return services.NewLocalEmbedder(), nil
+	case "gemini":
+		return services.NewGeminiEmbedder(ApiKey, EmbeddingModel)
+	case "openai":
+		return services.NewOpenAIEmbedder(OpenAIBaseURL, OpenAIApiKey, EmbeddingModel), nil
+	default:
+		return nil, fmt.Errorf("unknown EMBEDDING_PROVIDER: %s", EmbeddingProvider)
+	}
+}
+
+func newSearchProvider() (utils.SearchProvider, error) {
+	switch SearchProvider {
+	case "google":
+		return utils.NewGoogleSearchProvider(GoogleSearchKey, GoogleSearchCX), nil
+	case "searxng":
+		return utils.NewSearXNGProvider(SearXNGURL), nil
+	case "fixture":
+		return utils.NewFixtureSearchProvider(SearchFixtureDir), nil
+	default:
+		return nil, fmt.Errorf("unknown SEARCH_PROVIDER: %s", SearchProvider)
+	}
+}
+
 func main() {
 	log.Printf("🚀 AI Routes Service başlatılıyor...")
 	log.Printf("📊 Config: GRPC Port: %s, HTTP Port: %s", GRPCPort, HTTPPort)
@@ -56,21 +176,133 @@ func main() {
 		AllowCredentials: false,
 	}))
 
+	// LLM provider'ı seç
+	provider, err := newLLMProvider()
+	if err != nil {
+		log.Fatalf("❌ LLM provider initialization failed: %v", err)
+	}
+	log.Printf("🤖 LLM Provider: %s, Model: %s", LLMProvider, provider.ModelName())
+
+	search, err := newSearchProvider()
+	if err != nil {
+		log.Fatalf("❌ Search provider initialization failed: %v", err)
+	}
+	log.Printf("🔍 Search Provider: %s", SearchProvider)
+
 	// AI Service initialize et
-	aiService, err := services.NewAIService(ApiKey, ModelName, GoogleSearchKey, GoogleSearchCX)
+	aiService, err := services.NewAIService(provider, search)
 	if err != nil {
 		log.Fatalf("❌ AI service initialization failed: %v", err)
 	}
+	if aiService.MaxRepairRounds, err = strconv.Atoi(RepairRounds); err != nil {
+		log.Fatalf("❌ Invalid REPAIR_ROUNDS: %v", err)
+	}
+	aiService.StrictMode = StrictMode == "true"
+	switch GeoRegionFile {
+	case "none":
+		aiService.Geo = nil
+	case "":
+	default:
+		region, err := geo.LoadPolygon(GeoRegionFile)
+		if err != nil {
+			log.Fatalf("❌ Invalid GEO_REGION_FILE: %v", err)
+		}
+		aiService.Geo.Region = region
+	}
+	if aiService.Geo != nil {
+		if aiService.Geo.CityRadiusKm, err = strconv.ParseFloat(GeoCityRadiusKm, 64); err != nil {
+			log.Fatalf("❌ Invalid GEO_CITY_RADIUS_KM: %v", err)
+		}
+	}
+	switch Geocoder {
+	case "none":
+	case "nominatim":
+		geocoder := utils.NewNominatimGeocoder(GeocoderURL, GeocoderUserAgent)
+		geocoder.CountryCodes = GeocoderCountries
+		aiService.Geocoding = services.NewGeoCorrector(geocoder)
+		if aiService.Geocoding.ThresholdKm, err = strconv.ParseFloat(GeocodeThresholdKm, 64); err != nil {
+			log.Fatalf("❌ Invalid GEOCODE_THRESHOLD_KM: %v", err)
+		}
+		log.Printf("📍 Geocoder: %s", GeocoderURL)
+	default:
+		log.Fatalf("❌ Unknown GEOCODER: %s", Geocoder)
+	}
+	if aiService.Distances.MinKm, err = strconv.ParseFloat(DailyMinKm, 64); err != nil {
+		log.Fatalf("❌ Invalid DAILY_MIN_KM: %v", err)
+	}
+	if aiService.Distances.MaxKm, err = strconv.ParseFloat(DailyMaxKm, 64); err != nil {
+		log.Fatalf("❌ Invalid DAILY_MAX_KM: %v", err)
+	}
+	if OSRMURL != "" {
+		aiService.Distances.Router = utils.NewOSRMRouter(OSRMURL)
+		log.Printf("🛣️ Router: %s", OSRMURL)
+	}
+	if OptimizeRoute != "true" {
+		aiService.Optimizer = nil
+	}
+	switch LinkCheck {
+	case "off":
+	case "flag", "drop":
+		aiService.Links = services.NewLinkChecker()
+		aiService.Links.DropDead = LinkCheck == "drop"
+		aiService.Links.CheckTitle = LinkCheckTitle == "true"
+		if aiService.Links.Concurrency, err = strconv.Atoi(LinkCheckConcurrency); err != nil {
+			log.Fatalf("❌ Invalid LINK_CHECK_CONCURRENCY: %v", err)
+		}
+	default:
+		log.Fatalf("❌ Unknown LINK_CHECK: %s", LinkCheck)
+	}
+	if StorageDriver != "none" {
+		store, err := storage.Open(StorageDriver, StorageDSN)
+		if err != nil {
+			log.Fatalf("❌ Storage initialization failed: %v", err)
+		}
+		defer store.Close()
+		aiService.Store = store
+	}
+	if CatalogueDSN != "" {
+		catalogue, err := storage.OpenCatalogue(CatalogueDSN)
+		if err != nil {
+			log.Fatalf("❌ Catalogue initialization failed: %v", err)
+		}
+		defer catalogue.Close()
+		aiService.Campsites = services.NewCampsiteRetriever(catalogue)
+		if aiService.Campsites.CorridorKm, err = strconv.ParseFloat(CatalogueCorridorKm, 64); err != nil {
+			log.Fatalf("❌ Invalid CATALOGUE_CORRIDOR_KM: %v", err)
+		}
+		log.Printf("🏕️ Campsite catalogue: %s", CatalogueDSN)
+
+		if aiService.Campsites.Embedder, err = newEmbedder(); err != nil {
+			log.Fatalf("❌ Embedder initialization failed: %v", err)
+		}
+		if aiService.Campsites.Embedder != nil {
+			if err := aiService.Campsites.IndexCampsites(context.Background()); err != nil {
+				log.Printf("⚠️ Campsite index build failed, description search disabled: %v", err)
+				aiService.Campsites.Embedder = nil
+			}
+		}
+	}
 	log.Printf("✅ AI Service başarıyla oluşturuldu")
 
+	workers, err := strconv.Atoi(JobWorkers)
+	if err != nil {
+		log.Fatalf("❌ Invalid JOB_WORKERS: %v", err)
+	}
+	queueSize, err := strconv.Atoi(JobQueueSize)
+	if err != nil {
+		log.Fatalf("❌ Invalid JOB_QUEUE_SIZE: %v", err)
+	}
+	jobs := services.NewJobQueue(aiService, workers, queueSize)
+	defer jobs.Shutdown()
+
 	// gRPC Server'ı goroutine'de başlat
 	go func() {
 		log.Printf("🔧 gRPC Server başlatılıyor - Port: %s", GRPCPort)
-		grpc.StartGRPCServer(aiService, GRPCPort)
+		grpc.StartGRPCServer(aiService, jobs, GRPCPort)
 	}()
 
 	// HTTP Handler'ı oluştur
-	aiHandler := handler.NewAIHandler(aiService)
+	aiHandler := handler.NewAIHandler(aiService, jobs)
 
 	// Routes'ları kaydet
 	routes.AIRoute(app, aiHandler)
@@ -78,7 +310,7 @@ func main() {
 	// Health check endpoint
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
-			"status": "healthy",
+			"status":  "healthy",
 			"service": "ai-routes-service",
 		})
 	})
@@ -89,4 +321,4 @@ func main() {
 	if err != nil {
 		log.Fatalf("❌ HTTP Server başlatılamadı: %v", err)
 	}
-}
\ No newline at end of file
+}
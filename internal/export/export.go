@@ -0,0 +1,85 @@
+package export
+
+import (
+	"ai-routes-service/internal/models"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Planlar navigasyon uygulamaları için GPX, KML veya GeoJSON'a çevrilir.
+// Konaklar waypoint, başlangıçtan bitişe günlük etaplar tek bir track olarak yazılır.
+// Desteklenen formatlar (URL uzantısı):
+const (
+	FormatGPX     = "gpx"
+	FormatKML     = "kml"
+	FormatGeoJSON = "geojson"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// waypoint koordinatı bilinen tek bir konak
+type waypoint struct {
+	Daily models.DailyPlan
+	Lat   float64
+	Lon   float64
+}
+
+func (w waypoint) title() string {
+	return fmt.Sprintf("Gün %d: %s", w.Daily.Day, w.Daily.Location.Name)
+}
+
+func (w waypoint) description() string {
+	parts := []string{}
+	for _, s := range []string{w.Daily.Date, w.Daily.Location.Address, w.Daily.Location.Notes, w.Daily.Location.SiteURL} {
+		if strings.TrimSpace(s) != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Koordinatı olmayan günler atlanır
+func waypoints(plan *models.TripPlan) []waypoint {
+	points := []waypoint{}
+	for _, daily := range plan.DailyPlan {
+		loc := daily.Location
+		if loc.Latitude == 0 && loc.Longitude == 0 {
+			continue
+		}
+		points = append(points, waypoint{Daily: daily, Lat: loc.Latitude, Lon: loc.Longitude})
+	}
+	return points
+}
+
+// Track noktaları: biliniyorsa başlangıç, konaklar, biliniyorsa bitiş
+func track(plan *models.TripPlan) []models.Coordinates {
+	points := []models.Coordinates{}
+	if plan.Trip.StartCoordinates != nil {
+		points = append(points, *plan.Trip.StartCoordinates)
+	}
+	for _, w := range waypoints(plan) {
+		points = append(points, models.Coordinates{Latitude: w.Lat, Longitude: w.Lon})
+	}
+	if plan.Trip.EndCoordinates != nil {
+		points = append(points, *plan.Trip.EndCoordinates)
+	}
+	return points
+}
+
+// Render planı istenen formatta döndürür, Content-Type ile birlikte
+func Render(format string, plan *models.TripPlan) ([]byte, string, error) {
+	switch strings.ToLower(format) {
+	case FormatGPX:
+		data, err := GPX(plan)
+		return data, "application/gpx+xml", err
+	case FormatKML:
+		data, err := KML(plan)
+		return data, "application/vnd.google-earth.kml+xml", err
+	case FormatGeoJSON:
+		data, err := GeoJSON(plan)
+		return data, "application/geo+json", err
+	default:
+		return nil, "", fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"ai-routes-service/internal/models"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testPlan() *models.TripPlan {
+	return &models.TripPlan{
+		Trip: models.Trip{
+			Name:             "Likya Turu",
+			StartCoordinates: &models.Coordinates{Latitude: 36.6217, Longitude: 29.1164},
+			EndCoordinates:   &models.Coordinates{Latitude: 36.8969, Longitude: 30.7133},
+		},
+		DailyPlan: []models.DailyPlan{
+			{Day: 1, Date: "2025-08-01", Location: models.Location{Name: "Kabak Koyu", Address: "Faralya, Fethiye", Latitude: 36.4611, Longitude: 29.1258, SiteURL: "https://example.com"}},
+			{Day: 2, Date: "2025-08-02", Location: models.Location{Name: "Koordinatsız Kamp"}},
+			{Day: 3, Date: "2025-08-03", Location: models.Location{Name: "Kaş Camping", Latitude: 36.2012, Longitude: 29.6312}},
+		},
+	}
+}
+
+func TestGPX(t *testing.T) {
+	data, err := GPX(testPlan())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc gpxDoc
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid GPX: %v\n%s", err, data)
+	}
+	if len(doc.Waypoints) != 2 || doc.Waypoints[0].Name != "Gün 1: Kabak Koyu" || doc.Waypoints[0].Link == nil {
+		t.Errorf("waypoints = %+v", doc.Waypoints)
+	}
+	// Başlangıç + 2 konak + bitiş
+	if doc.Track == nil || len(doc.Track.Segment) != 4 || doc.Track.Segment[1].Lat != 36.4611 {
+		t.Errorf("track = %+v", doc.Track)
+	}
+}
+
+func TestKML(t *testing.T) {
+	data, err := KML(testPlan())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc kmlDoc
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid KML: %v\n%s", err, data)
+	}
+	placemarks := doc.Document.Placemarks
+	if len(placemarks) != 3 || placemarks[0].Point.Coordinates != "29.125800,36.461100" {
+		t.Fatalf("placemarks = %+v", placemarks)
+	}
+	if line := placemarks[2].LineString; line == nil || len(strings.Fields(line.Coordinates)) != 4 {
+		t.Errorf("route = %+v", placemarks[2])
+	}
+}
+
+func TestGeoJSON(t *testing.T) {
+	data, _, err := Render("GeoJSON", testPlan())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(data, &collection); err != nil {
+		t.Fatal(err)
+	}
+	if collection.Type != "FeatureCollection" || len(collection.Features) != 3 {
+		t.Fatalf("collection = %s", data)
+	}
+	var point []float64
+	if err := json.Unmarshal(collection.Features[1].Geometry.Coordinates, &point); err != nil || len(point) != 2 || point[0] != 29.6312 {
+		t.Errorf("point coordinates = %v, want [lon, lat]", point)
+	}
+	if collection.Features[2].Geometry.Type != "LineString" {
+		t.Errorf("last feature = %+v", collection.Features[2])
+	}
+
+	if _, _, err := Render("pdf", testPlan()); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("unknown format err = %v", err)
+	}
+}
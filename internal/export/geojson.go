@@ -0,0 +1,59 @@
+package export
+
+import (
+	"ai-routes-service/internal/models"
+	"encoding/json"
+)
+
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	Type       string         `json:"type"`
+	Geometry   geometry       `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+type geometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// GeoJSON (RFC 7946) FeatureCollection üretir: her konak bir Point,
+// rota bir LineString. Koordinatlar [boylam, enlem] sırasındadır.
+func GeoJSON(plan *models.TripPlan) ([]byte, error) {
+	collection := featureCollection{Type: "FeatureCollection", Features: []feature{}}
+	for _, w := range waypoints(plan) {
+		loc := w.Daily.Location
+		collection.Features = append(collection.Features, feature{
+			Type:     "Feature",
+			Geometry: geometry{Type: "Point", Coordinates: []float64{w.Lon, w.Lat}},
+			Properties: map[string]any{
+				"day":         w.Daily.Day,
+				"date":        w.Daily.Date,
+				"name":        loc.Name,
+				"address":     loc.Address,
+				"site_url":    loc.SiteURL,
+				"notes":       loc.Notes,
+				"distance_km": w.Daily.DistanceKm,
+			},
+		})
+	}
+	if points := track(plan); len(points) > 1 {
+		coords := make([][]float64, len(points))
+		for i, p := range points {
+			coords[i] = []float64{p.Longitude, p.Latitude}
+		}
+		collection.Features = append(collection.Features, feature{
+			Type:     "Feature",
+			Geometry: geometry{Type: "LineString", Coordinates: coords},
+			Properties: map[string]any{
+				"name":              plan.Trip.Name,
+				"total_distance_km": plan.Trip.TotalDistanceKm,
+			},
+		})
+	}
+	return json.MarshalIndent(collection, "", "  ")
+}
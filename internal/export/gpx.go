@@ -0,0 +1,67 @@
+package export
+
+import (
+	"ai-routes-service/internal/models"
+	"encoding/xml"
+)
+
+type gpxDoc struct {
+	XMLName   xml.Name      `xml:"gpx"`
+	Version   string        `xml:"version,attr"`
+	Creator   string        `xml:"creator,attr"`
+	Xmlns     string        `xml:"xmlns,attr"`
+	Metadata  gpxMetadata   `xml:"metadata"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+	Track     *gpxTrack     `xml:"trk,omitempty"`
+}
+
+type gpxMetadata struct {
+	Name string `xml:"name"`
+	Desc string `xml:"desc,omitempty"`
+}
+
+type gpxWaypoint struct {
+	Lat  float64  `xml:"lat,attr"`
+	Lon  float64  `xml:"lon,attr"`
+	Name string   `xml:"name"`
+	Desc string   `xml:"desc,omitempty"`
+	Link *gpxLink `xml:"link,omitempty"`
+}
+
+type gpxLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type gpxTrack struct {
+	Name    string        `xml:"name"`
+	Segment []gpxWaypoint `xml:"trkseg>trkpt"`
+}
+
+// GPX 1.1 dokümanı üretir
+func GPX(plan *models.TripPlan) ([]byte, error) {
+	doc := gpxDoc{
+		Version:  "1.1",
+		Creator:  "ai-routes-service",
+		Xmlns:    "http://www.topografix.com/GPX/1/1",
+		Metadata: gpxMetadata{Name: plan.Trip.Name, Desc: plan.Trip.RouteSummary},
+	}
+	for _, w := range waypoints(plan) {
+		wpt := gpxWaypoint{Lat: w.Lat, Lon: w.Lon, Name: w.title(), Desc: w.description()}
+		if w.Daily.Location.SiteURL != "" {
+			wpt.Link = &gpxLink{Href: w.Daily.Location.SiteURL}
+		}
+		doc.Waypoints = append(doc.Waypoints, wpt)
+	}
+	if points := track(plan); len(points) > 1 {
+		doc.Track = &gpxTrack{Name: plan.Trip.Name}
+		for _, p := range points {
+			doc.Track.Segment = append(doc.Track.Segment, gpxWaypoint{Lat: p.Latitude, Lon: p.Longitude})
+		}
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
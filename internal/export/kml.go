@@ -0,0 +1,72 @@
+package export
+
+import (
+	"ai-routes-service/internal/models"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type kmlDoc struct {
+	XMLName  xml.Name    `xml:"kml"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Document kmlDocument `xml:"Document"`
+}
+
+type kmlDocument struct {
+	Name        string         `xml:"name"`
+	Description string         `xml:"description,omitempty"`
+	Placemarks  []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string         `xml:"name"`
+	Description string         `xml:"description,omitempty"`
+	Point       *kmlPoint      `xml:"Point,omitempty"`
+	LineString  *kmlLineString `xml:"LineString,omitempty"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlLineString struct {
+	Tessellate  int    `xml:"tessellate"`
+	Coordinates string `xml:"coordinates"`
+}
+
+// KML koordinatları boylam,enlem sırasıyla yazılır
+func kmlCoord(lat, lon float64) string {
+	return fmt.Sprintf("%.6f,%.6f", lon, lat)
+}
+
+// KML 2.2 dokümanı üretir
+func KML(plan *models.TripPlan) ([]byte, error) {
+	doc := kmlDoc{
+		Xmlns:    "http://www.opengis.net/kml/2.2",
+		Document: kmlDocument{Name: plan.Trip.Name, Description: plan.Trip.RouteSummary},
+	}
+	for _, w := range waypoints(plan) {
+		doc.Document.Placemarks = append(doc.Document.Placemarks, kmlPlacemark{
+			Name:        w.title(),
+			Description: w.description(),
+			Point:       &kmlPoint{Coordinates: kmlCoord(w.Lat, w.Lon)},
+		})
+	}
+	if points := track(plan); len(points) > 1 {
+		coords := make([]string, len(points))
+		for i, p := range points {
+			coords[i] = kmlCoord(p.Latitude, p.Longitude)
+		}
+		doc.Document.Placemarks = append(doc.Document.Placemarks, kmlPlacemark{
+			Name:       "Rota",
+			LineString: &kmlLineString{Tessellate: 1, Coordinates: strings.Join(coords, " ")},
+		})
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,156 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+)
+
+const earthRadiusKm = 6371.0
+
+// Point WGS84 koordinatı
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// HaversineKm iki nokta arasındaki büyük daire mesafesi (km)
+func HaversineKm(a, b Point) float64 {
+	lat1, lat2 := toRad(a.Lat), toRad(b.Lat)
+	dLat := lat2 - lat1
+	dLon := toRad(b.Lon - a.Lon)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func toRad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// Polygon kapalı bir bölge sınırı (son nokta ilk noktaya bağlanır)
+type Polygon []Point
+
+// Contains noktanın poligon içinde olup olmadığını ray casting ile kontrol eder
+func (p Polygon) Contains(pt Point) bool {
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+		if (a.Lat > pt.Lat) != (b.Lat > pt.Lat) &&
+			pt.Lon < (b.Lon-a.Lon)*(pt.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DistanceKm noktanın poligon sınırına en yakın mesafesi (km). Kısa kenarlar
+// için yeterli olan eşdikdörtgen izdüşüm kullanılır.
+func (p Polygon) DistanceKm(pt Point) float64 {
+	best := math.Inf(1)
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		best = math.Min(best, segmentDistanceKm(pt, p[j], p[i]))
+	}
+	return best
+}
+
+// ContainsWithin nokta poligonun içinde veya sınıra toleranceKm'den yakınsa true döner.
+// Kaba çizilmiş sınırlarda kıyıdaki noktaların yanlışlıkla dışarıda sayılmasını önler.
+func (p Polygon) ContainsWithin(pt Point, toleranceKm float64) bool {
+	return p.Contains(pt) || p.DistanceKm(pt) <= toleranceKm
+}
+
+func segmentDistanceKm(pt, a, b Point) float64 {
+	km, _ := SegmentProjection(pt, a, b)
+	return km
+}
+
+// SegmentProjection noktanın a-b doğru parçasına uzaklığını (km) ve en yakın
+// noktanın parça üzerindeki konumunu (0 = a, 1 = b) döndürür
+func SegmentProjection(pt, a, b Point) (float64, float64) {
+	// pt merkezli düzlemde km cinsinden koordinatlar
+	kx := earthRadiusKm * math.Cos(toRad(pt.Lat)) * math.Pi / 180
+	ky := earthRadiusKm * math.Pi / 180
+	ax, ay := (a.Lon-pt.Lon)*kx, (a.Lat-pt.Lat)*ky
+	bx, by := (b.Lon-pt.Lon)*kx, (b.Lat-pt.Lat)*ky
+
+	dx, dy := bx-ax, by-ay
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/l))
+	}
+	return math.Hypot(ax+t*dx, ay+t*dy), t
+}
+
+// BBox enlem/boylam sınırlarıyla dikdörtgen alan
+type BBox struct {
+	MinLat, MaxLat float64
+	MinLon, MaxLon float64
+}
+
+// CorridorBox a-b doğru parçasını her yönde marginKm genişleten kutu
+func CorridorBox(a, b Point, marginKm float64) BBox {
+	dLat := marginKm / (earthRadiusKm * math.Pi / 180)
+	// Kutunun ekvatordan uzak kenarında boylam derecesi daha kısadır
+	maxAbsLat := math.Min(89, math.Max(math.Abs(a.Lat), math.Abs(b.Lat))+dLat)
+	dLon := marginKm / (earthRadiusKm * math.Cos(toRad(maxAbsLat)) * math.Pi / 180)
+	return BBox{
+		MinLat: math.Min(a.Lat, b.Lat) - dLat,
+		MaxLat: math.Max(a.Lat, b.Lat) + dLat,
+		MinLon: math.Min(a.Lon, b.Lon) - dLon,
+		MaxLon: math.Max(a.Lon, b.Lon) + dLon,
+	}
+}
+
+// LoadPolygon GeoJSON dosyasından (Polygon, Feature veya FeatureCollection'daki
+// ilk Polygon) dış sınırı okur
+func LoadPolygon(path string) (Polygon, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Type        string            `json:"type"`
+		Coordinates [][][2]float64    `json:"coordinates"`
+		Geometry    *json.RawMessage  `json:"geometry"`
+		Features    []json.RawMessage `json:"features"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for {
+		switch doc.Type {
+		case "Polygon":
+			if len(doc.Coordinates) == 0 || len(doc.Coordinates[0]) < 3 {
+				return nil, fmt.Errorf("%s: polygon has no outer ring", path)
+			}
+			polygon := make(Polygon, 0, len(doc.Coordinates[0]))
+			for _, c := range doc.Coordinates[0] {
+				// GeoJSON sırası [lon, lat]
+				polygon = append(polygon, Point{Lat: c[1], Lon: c[0]})
+			}
+			return polygon, nil
+		case "Feature":
+			if doc.Geometry == nil {
+				return nil, fmt.Errorf("%s: feature has no geometry", path)
+			}
+			data = *doc.Geometry
+		case "FeatureCollection":
+			if len(doc.Features) == 0 {
+				return nil, fmt.Errorf("%s: feature collection is empty", path)
+			}
+			data = doc.Features[0]
+		default:
+			return nil, errors.New(path + ": unsupported GeoJSON type " + doc.Type)
+		}
+
+		doc.Type, doc.Coordinates, doc.Geometry, doc.Features = "", nil, nil, nil
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+}
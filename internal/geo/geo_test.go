@@ -0,0 +1,131 @@
+package geo
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHaversineKm(t *testing.T) {
+	istanbul := Point{41.0082, 28.9784}
+	ankara := Point{39.9334, 32.8597}
+	if d := HaversineKm(istanbul, ankara); math.Abs(d-350) > 5 {
+		t.Errorf("İstanbul-Ankara = %.1f km, want ~350", d)
+	}
+	if d := HaversineKm(ankara, ankara); d != 0 {
+		t.Errorf("same point = %f", d)
+	}
+}
+
+func TestTurkeyRegion(t *testing.T) {
+	for _, p := range Provinces {
+		if !Turkey.ContainsWithin(p.Center, 10) {
+			t.Errorf("%s center is outside the region", p.Name)
+		}
+	}
+
+	inside := map[string]Point{
+		"Kaş":      {36.2012, 29.6312},
+		"Kuşadası": {37.8654, 27.2543},
+		"Gökçeada": {40.19, 25.85},
+		"Anamur":   {36.07, 32.83},
+		"Hopa":     {41.39, 41.43},
+		"İğneada":  {41.87, 27.98},
+		"Samandağ": {36.08, 35.97},
+		"Babakale": {39.48, 26.06},
+	}
+	for name, pt := range inside {
+		if !Turkey.ContainsWithin(pt, 10) {
+			t.Errorf("%s should be inside (%.1f km from border)", name, Turkey.DistanceKm(pt))
+		}
+	}
+
+	outside := map[string]Point{
+		"Karadeniz ortası": {42.8, 34.0},
+		"Akdeniz":          {35.2, 31.0},
+		"Kıbrıs":           {35.1, 33.4},
+		"Atina":            {37.98, 23.72},
+		"Tiflis":           {41.7, 44.8},
+		"0,0":              {0, 0},
+	}
+	for name, pt := range outside {
+		if Turkey.ContainsWithin(pt, 10) {
+			t.Errorf("%s should be outside", name)
+		}
+	}
+}
+
+func TestFindProvince(t *testing.T) {
+	tests := map[string]string{
+		"Kalkan Mah. Kaş/ANTALYA":        "Antalya",
+		"Türkmen Mah., Kuşadası, Aydın":  "Aydın",
+		"IĞDIR merkez":                   "Iğdır",
+		"Haliliye, Urfa":                 "Şanlıurfa",
+		"İzmir yolu üzeri, Çeşme, İZMİR": "İzmir",
+	}
+	for text, want := range tests {
+		p, ok := FindProvince(text)
+		if !ok || p.Name != want {
+			t.Errorf("FindProvince(%q) = %q, %v; want %q", text, p.Name, ok, want)
+		}
+	}
+	if _, ok := FindProvince("Olympos Orange Camp"); ok {
+		t.Error("campsite name without a province should not match")
+	}
+}
+
+func TestLoadPolygonFeature(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "region.geojson")
+	data := `{"type":"FeatureCollection","features":[{"type":"Feature","properties":{},"geometry":{"type":"Polygon","coordinates":[[[26,36],[45,36],[45,42],[26,42],[26,36]]]}}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	polygon, err := LoadPolygon(path)
+	if err != nil {
+		t.Fatalf("LoadPolygon: %v", err)
+	}
+	if len(polygon) != 5 || polygon[1] != (Point{Lat: 36, Lon: 45}) {
+		t.Errorf("polygon = %v", polygon)
+	}
+	if !polygon.Contains(Point{39, 32}) || polygon.Contains(Point{35, 32}) {
+		t.Error("Contains gave wrong result for the loaded polygon")
+	}
+}
+
+func TestOrderStops(t *testing.T) {
+	ankara := Point{39.9334, 32.8597}
+	antalya := Point{36.8969, 30.7133}
+	// Ankara'dan Antalya'ya giderken Kaş ve Afyon yer değiştirmiş
+	stops := []Point{
+		{36.2012, 29.6312}, // Kaş
+		{38.7507, 30.5567}, // Afyon
+		{37.7648, 30.5566}, // Isparta
+	}
+	identity := []int{0, 1, 2}
+	order := OrderStops(&ankara, &antalya, stops)
+	if want := []int{1, 2, 0}; !equalInts(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if PathKm(&ankara, &antalya, stops, order) >= PathKm(&ankara, &antalya, stops, identity) {
+		t.Errorf("optimized path is not shorter")
+	}
+
+	// Zaten iyi olan sıra değişmez
+	if order := OrderStops(&ankara, &antalya, []Point{stops[1], stops[2]}); !equalInts(order, []int{0, 1}) {
+		t.Errorf("good order changed: %v", order)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package geo
+
+// PathKm start'tan başlayıp stops'u order sırasıyla dolaşarak end'e giden
+// yolun kuş uçuşu uzunluğu. start veya end nil ise o uç hesaba katılmaz.
+func PathKm(start, end *Point, stops []Point, order []int) float64 {
+	total := 0.0
+	prev := start
+	for _, i := range order {
+		if prev != nil {
+			total += HaversineKm(*prev, stops[i])
+		}
+		prev = &stops[i]
+	}
+	if prev != nil && end != nil {
+		total += HaversineKm(*prev, *end)
+	}
+	return total
+}
+
+// OrderStops uçları sabit açık yol için toplam mesafeyi kısaltan bir durak
+// sırası döndürür. Verilen sıra ve en yakın komşu sırası 2-opt ile
+// iyileştirilir, kısa olan seçilir; sonuç hiçbir zaman verilen sıradan uzun değildir.
+func OrderStops(start, end *Point, stops []Point) []int {
+	original := make([]int, len(stops))
+	for i := range original {
+		original[i] = i
+	}
+	if len(stops) < 2 {
+		return original
+	}
+
+	best := twoOpt(start, end, stops, original)
+	if nn := twoOpt(start, end, stops, nearestNeighbour(start, stops)); PathKm(start, end, stops, nn) < PathKm(start, end, stops, best) {
+		best = nn
+	}
+	return best
+}
+
+func nearestNeighbour(start *Point, stops []Point) []int {
+	visited := make([]bool, len(stops))
+	order := make([]int, 0, len(stops))
+	prev := start
+	for len(order) < len(stops) {
+		next := -1
+		for i := range stops {
+			if visited[i] {
+				continue
+			}
+			// Başlangıç bilinmiyorsa ilk kalan duraktan başla
+			if next == -1 || (prev != nil && HaversineKm(*prev, stops[i]) < HaversineKm(*prev, stops[next])) {
+				next = i
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+		prev = &stops[next]
+	}
+	return order
+}
+
+// twoOpt yolu kısalttığı sürece order'ın alt dizilerini ters çevirir
+func twoOpt(start, end *Point, stops []Point, order []int) []int {
+	order = append([]int(nil), order...)
+	bestKm := PathKm(start, end, stops, order)
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(order)-1; i++ {
+			for j := i + 1; j < len(order); j++ {
+				reverse(order[i : j+1])
+				if km := PathKm(start, end, stops, order); km < bestKm-1e-9 {
+					bestKm, improved = km, true
+				} else {
+					reverse(order[i : j+1])
+				}
+			}
+		}
+	}
+	return order
+}
+
+func reverse(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,117 @@
+package geo
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Turkey kıyı şeridini ve kara sınırlarını kabaca izleyen varsayılan bölge.
+// Ege adaları (Gökçeada, Bozcaada) dahil; kıyıdaki sapmalar için
+// ContainsWithin ile birkaç km tolerans kullanılmalı.
+var Turkey = Polygon{
+	// Trakya, Bulgaristan sınırı ve Karadeniz kıyısı
+	{41.98, 28.00}, {41.65, 28.10}, {41.36, 28.70}, {41.25, 29.05},
+	// Karadeniz
+	{41.18, 29.61}, {41.18, 30.25}, {41.10, 30.69}, {41.09, 31.12}, {41.46, 31.79},
+	{41.75, 32.39}, {41.89, 33.00}, {41.98, 33.76}, {42.10, 34.98}, {42.03, 35.15},
+	{41.80, 35.20}, {41.72, 35.95}, {41.29, 36.33}, {41.13, 37.28}, {40.98, 37.88},
+	{40.91, 38.39}, {41.00, 39.72}, {41.02, 40.52}, {41.39, 41.43}, {41.52, 41.55},
+	// Gürcistan, Ermenistan, Nahçıvan ve İran sınırları
+	{41.45, 42.50}, {41.58, 42.83}, {41.12, 43.47}, {40.97, 43.58}, {40.63, 43.73},
+	{40.36, 43.66}, {40.03, 43.90}, {40.03, 44.35}, {39.71, 44.77}, {39.40, 44.40},
+	{38.80, 44.30}, {38.30, 44.30}, {37.80, 44.45}, {37.32, 44.80}, {37.15, 44.79},
+	// Irak ve Suriye sınırları
+	{37.30, 44.00}, {37.25, 43.40}, {37.33, 42.80}, {37.11, 42.36}, {37.08, 41.30},
+	{36.82, 40.00}, {36.70, 39.00}, {36.84, 38.20}, {36.65, 37.20}, {36.83, 36.66},
+	{36.50, 36.55}, {36.21, 36.39}, {35.82, 36.15}, {35.92, 35.92},
+	// Akdeniz
+	{36.08, 35.96}, {36.41, 35.88}, {36.59, 36.17}, {36.77, 35.79}, {36.56, 35.38},
+	{36.78, 34.64}, {36.32, 33.88}, {36.02, 32.83}, {36.54, 32.00}, {36.88, 30.70},
+	{36.60, 30.56}, {36.21, 30.41}, {36.29, 30.15}, {36.20, 29.64}, {36.26, 29.32},
+	{36.62, 29.11}, {36.80, 28.64}, {36.85, 28.27}, {36.68, 27.37},
+	// Ege
+	{37.03, 27.43}, {37.37, 27.26}, {37.86, 27.26}, {38.32, 26.30}, {38.70, 26.50},
+	{38.67, 26.75}, {39.07, 26.89}, {39.32, 26.69}, {39.48, 26.06}, {39.80, 26.07},
+	{40.05, 25.60}, {40.30, 25.60}, {40.40, 26.10}, {40.72, 26.04},
+	// Yunanistan sınırı (Meriç)
+	{40.92, 26.38}, {41.35, 26.35}, {41.71, 26.36}, {41.95, 26.60}, {42.07, 27.00},
+	{41.93, 27.56},
+}
+
+// Province il merkezi
+type Province struct {
+	Name   string
+	Center Point
+}
+
+// Provinces 81 il merkezi (plaka sırasıyla)
+var Provinces = []Province{
+	{"Adana", Point{37.00, 35.32}}, {"Adıyaman", Point{37.76, 38.28}}, {"Afyonkarahisar", Point{38.76, 30.54}},
+	{"Ağrı", Point{39.72, 43.05}}, {"Amasya", Point{40.65, 35.83}}, {"Ankara", Point{39.93, 32.86}},
+	{"Antalya", Point{36.90, 30.70}}, {"Artvin", Point{41.18, 41.82}}, {"Aydın", Point{37.85, 27.85}},
+	{"Balıkesir", Point{39.65, 27.88}}, {"Bilecik", Point{40.14, 29.98}}, {"Bingöl", Point{38.88, 40.50}},
+	{"Bitlis", Point{38.40, 42.11}}, {"Bolu", Point{40.74, 31.61}}, {"Burdur", Point{37.72, 30.29}},
+	{"Bursa", Point{40.19, 29.06}}, {"Çanakkale", Point{40.15, 26.41}}, {"Çankırı", Point{40.60, 33.62}},
+	{"Çorum", Point{40.55, 34.96}}, {"Denizli", Point{37.78, 29.09}}, {"Diyarbakır", Point{37.91, 40.24}},
+	{"Edirne", Point{41.68, 26.56}}, {"Elazığ", Point{38.68, 39.22}}, {"Erzincan", Point{39.75, 39.49}},
+	{"Erzurum", Point{39.90, 41.27}}, {"Eskişehir", Point{39.78, 30.52}}, {"Gaziantep", Point{37.07, 37.38}},
+	{"Giresun", Point{40.91, 38.39}}, {"Gümüşhane", Point{40.46, 39.48}}, {"Hakkari", Point{37.58, 43.74}},
+	{"Hatay", Point{36.20, 36.16}}, {"Isparta", Point{37.76, 30.55}}, {"Mersin", Point{36.81, 34.64}},
+	{"İstanbul", Point{41.01, 28.98}}, {"İzmir", Point{38.42, 27.14}}, {"Kars", Point{40.60, 43.10}},
+	{"Kastamonu", Point{41.38, 33.78}}, {"Kayseri", Point{38.73, 35.49}}, {"Kırklareli", Point{41.74, 27.22}},
+	{"Kırşehir", Point{39.15, 34.16}}, {"Kocaeli", Point{40.77, 29.92}}, {"Konya", Point{37.87, 32.48}},
+	{"Kütahya", Point{39.42, 29.98}}, {"Malatya", Point{38.36, 38.31}}, {"Manisa", Point{38.61, 27.43}},
+	{"Kahramanmaraş", Point{37.58, 36.94}}, {"Mardin", Point{37.31, 40.74}}, {"Muğla", Point{37.22, 28.36}},
+	{"Muş", Point{38.75, 41.51}}, {"Nevşehir", Point{38.62, 34.71}}, {"Niğde", Point{37.97, 34.68}},
+	{"Ordu", Point{40.98, 37.88}}, {"Rize", Point{41.02, 40.52}}, {"Sakarya", Point{40.78, 30.40}},
+	{"Samsun", Point{41.29, 36.33}}, {"Siirt", Point{37.93, 41.94}}, {"Sinop", Point{42.03, 35.15}},
+	{"Sivas", Point{39.75, 37.02}}, {"Tekirdağ", Point{40.98, 27.51}}, {"Tokat", Point{40.31, 36.55}},
+	{"Trabzon", Point{41.00, 39.72}}, {"Tunceli", Point{39.11, 39.55}}, {"Şanlıurfa", Point{37.16, 38.79}},
+	{"Uşak", Point{38.68, 29.41}}, {"Van", Point{38.49, 43.38}}, {"Yozgat", Point{39.82, 34.81}},
+	{"Zonguldak", Point{41.45, 31.79}}, {"Aksaray", Point{38.37, 34.03}}, {"Bayburt", Point{40.26, 40.23}},
+	{"Karaman", Point{37.18, 33.22}}, {"Kırıkkale", Point{39.85, 33.51}}, {"Batman", Point{37.89, 41.13}},
+	{"Şırnak", Point{37.52, 42.46}}, {"Bartın", Point{41.64, 32.34}}, {"Ardahan", Point{41.11, 42.70}},
+	{"Iğdır", Point{39.92, 44.04}}, {"Yalova", Point{40.65, 29.27}}, {"Karabük", Point{41.20, 32.62}},
+	{"Kilis", Point{36.72, 37.12}}, {"Osmaniye", Point{37.07, 36.25}}, {"Düzce", Point{40.84, 31.16}},
+}
+
+// Adreslerde sık geçen eski veya kısa il adları
+var provinceAliases = map[string]string{
+	"afyon":     "Afyonkarahisar",
+	"maraş":     "Kahramanmaraş",
+	"urfa":      "Şanlıurfa",
+	"içel":      "Mersin",
+	"antakya":   "Hatay",
+	"izmit":     "Kocaeli",
+	"adapazarı": "Sakarya",
+}
+
+var provinceIndex = func() map[string]Province {
+	index := map[string]Province{}
+	for _, p := range Provinces {
+		index[normalizeName(p.Name)] = p
+	}
+	for alias, name := range provinceAliases {
+		index[alias] = index[normalizeName(name)]
+	}
+	return index
+}()
+
+// FindProvince metinde geçen il adını bulur. Adresler genelde "..., İlçe/İl"
+// şeklinde bittiği için sondan başa doğru aranır.
+func FindProvince(text string) (Province, bool) {
+	words := strings.FieldsFunc(normalizeName(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for i := len(words) - 1; i >= 0; i-- {
+		if p, ok := provinceIndex[words[i]]; ok {
+			return p, true
+		}
+	}
+	return Province{}, false
+}
+
+// Türkçe büyük/küçük harf kurallarıyla küçültür (İ→i, I→ı)
+func normalizeName(s string) string {
+	return strings.ToLowerSpecial(unicode.TurkishCase, strings.TrimSpace(s))
+}
@@ -0,0 +1,239 @@
+package grpc
+
+import (
+	"ai-routes-service/internal/grpc/planpb"
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/services"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Semhumc/grpc-proto/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PlanGrpcServer struct {
+	planpb.UnimplementedPlanServiceServer
+	AIService *services.AIService
+	Jobs      *services.JobQueue
+}
+
+func NewPlanGrpcServer(aiService *services.AIService, jobs *services.JobQueue) *PlanGrpcServer {
+	return &PlanGrpcServer{
+		AIService: aiService,
+		Jobs:      jobs,
+	}
+}
+
+func (s *PlanGrpcServer) SubmitPlan(ctx context.Context, req *planpb.PlanRequest) (*planpb.PlanJob, error) {
+	log.Printf("📥 gRPC SubmitPlan alındı: %+v", req)
+
+	prompt, err := planPromptBody(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.Jobs.Submit(prompt)
+	if err != nil {
+		if errors.Is(err, services.ErrJobQueueFull) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if errors.Is(err, services.ErrInvalidPrompt) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	return toProtoPlanJob(job), nil
+}
+
+// İstekteki tercihler varsa metadata okunmaz
+func planPromptBody(ctx context.Context, req *planpb.PlanRequest) (models.PromptBody, error) {
+	if req.Prompt == nil {
+		return models.PromptBody{}, status.Error(codes.InvalidArgument, "prompt is required")
+	}
+	if req.Preferences == nil {
+		return toPromptBody(ctx, req.Prompt)
+	}
+	prompt := promptFields(req.Prompt)
+	prompt.Preferences = fromProtoPreferences(req.Preferences)
+	return prompt, nil
+}
+
+// GetPlan HTTP GET /plans/{id} gibi kuyrukta olmayan job'ları kayıtlı plandan döndürür
+func (s *PlanGrpcServer) GetPlan(ctx context.Context, req *planpb.GetPlanRequest) (*planpb.PlanJob, error) {
+	job, err := s.Jobs.Lookup(ctx, s.AIService.Store, req.JobId)
+	if err != nil {
+		if errors.Is(err, services.ErrJobNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return toProtoPlanJob(job), nil
+}
+
+// StreamPlan GeneratePlan ile aynı hattı çalıştırır, her aşamayı ve model
+// ürettikçe günleri istemciye gönderir. Son mesaj tam planı taşır.
+func (s *PlanGrpcServer) StreamPlan(req *planpb.PlanRequest, stream planpb.PlanService_StreamPlanServer) error {
+	log.Printf("📡 gRPC StreamPlan alındı: %+v", req)
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// İlerleme callback'i GenerateTripPlan ile aynı goroutine'de çağrılır
+	var sendErr error
+	send := func(event models.ProgressEvent) {
+		if sendErr != nil {
+			return
+		}
+		if sendErr = stream.Send(toProtoPlanEvent(event)); sendErr != nil {
+			log.Printf("🛑 StreamPlan gönderilemedi: %v", sendErr)
+			cancel()
+		}
+	}
+
+	prompt, err := planPromptBody(ctx, req)
+	if err != nil {
+		return err
+	}
+	_, err = s.AIService.GenerateTripPlan(services.WithProgress(ctx, send), prompt)
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		log.Printf("❌ AI Service hatası: %v", err)
+		if errors.Is(err, services.ErrPlanUnavailable) {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		if errors.Is(err, services.ErrInvalidPrompt) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *PlanGrpcServer) ListPlans(ctx context.Context, req *planpb.ListPlansRequest) (*planpb.ListPlansResponse, error) {
+	if s.AIService.Store == nil {
+		return nil, status.Error(codes.Unimplemented, "plan storage is disabled")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	records, err := s.AIService.Store.ListPlans(ctx, req.UserId, int(req.Limit), int(req.Offset))
+	if err != nil {
+		log.Printf("❌ Plan listesi alınamadı: %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &planpb.ListPlansResponse{}
+	for _, record := range records {
+		response.Plans = append(response.Plans, toProtoStoredPlan(record))
+	}
+	return response, nil
+}
+
+var protoJobStatus = map[string]planpb.JobStatus{
+	models.JobStatusQueued:  planpb.JobStatus_JOB_STATUS_QUEUED,
+	models.JobStatusRunning: planpb.JobStatus_JOB_STATUS_RUNNING,
+	models.JobStatusDone:    planpb.JobStatus_JOB_STATUS_DONE,
+	models.JobStatusFailed:  planpb.JobStatus_JOB_STATUS_FAILED,
+}
+
+// PlanJob modelini proto mesajına çevirir
+func toProtoPlanJob(job models.PlanJob) *planpb.PlanJob {
+	response := &planpb.PlanJob{
+		JobId:      job.ID,
+		Status:     protoJobStatus[job.Status],
+		Error:      job.Error,
+		CreatedAt:  timestamppb.New(job.CreatedAt),
+		StartedAt:  toProtoTimestamp(job.StartedAt),
+		FinishedAt: toProtoTimestamp(job.FinishedAt),
+	}
+	if result := job.Result; result != nil {
+		response.Plan = toProtoTripPlan(result.Plan)
+		response.PlanStatus = result.Status
+		response.DegradedReason = result.DegradedReason
+		response.Violations = toProtoViolations(result.Violations)
+	}
+	return response
+}
+
+func toProtoPlanResult(result *models.PlanResult) *planpb.PlanResult {
+	return &planpb.PlanResult{
+		Plan:           toProtoTripPlan(result.Plan),
+		Status:         result.Status,
+		DegradedReason: result.DegradedReason,
+		Violations:     toProtoViolations(result.Violations),
+	}
+}
+
+func toProtoStoredPlan(record models.PlanRecord) *planpb.StoredPlan {
+	stored := &planpb.StoredPlan{
+		Id:          record.ID,
+		ParentId:    record.ParentID,
+		Version:     int32(record.Version),
+		Instruction: record.Instruction,
+		Prompt: &proto.PromptRequest{
+			UserId:        record.Prompt.UserID,
+			Name:          record.Prompt.Name,
+			Description:   record.Prompt.Description,
+			StartPosition: record.Prompt.StartPosition,
+			EndPosition:   record.Prompt.EndPosition,
+			StartDate:     record.Prompt.StartDate,
+			EndDate:       record.Prompt.EndDate,
+		},
+		Error:       record.Error,
+		Model:       record.Model,
+		StartedAt:   timestamppb.New(record.StartedAt),
+		FinishedAt:  timestamppb.New(record.FinishedAt),
+		DurationMs:  record.DurationMs,
+		Preferences: toProtoPreferences(record.Prompt.Preferences),
+	}
+	if record.Result != nil {
+		stored.Result = toProtoPlanResult(record.Result)
+	}
+	return stored
+}
+
+func toProtoViolations(violations []models.PlanViolation) []*planpb.PlanViolation {
+	var response []*planpb.PlanViolation
+	for _, v := range violations {
+		response = append(response, &planpb.PlanViolation{
+			Code:    v.Code,
+			Day:     int32(v.Day),
+			Message: v.Message,
+		})
+	}
+	return response
+}
+
+// ProgressEvent'i stream mesajına çevirir
+func toProtoPlanEvent(event models.ProgressEvent) *planpb.PlanEvent {
+	switch {
+	case event.Stage == models.ProgressPartialDay && event.Day != nil:
+		return &planpb.PlanEvent{Event: &planpb.PlanEvent_Day{Day: toProtoDailyPlan(*event.Day)}}
+	case event.Stage == models.ProgressPlan && event.Result != nil:
+		return &planpb.PlanEvent{Event: &planpb.PlanEvent_Result{Result: toProtoPlanResult(event.Result)}}
+	default:
+		return &planpb.PlanEvent{Event: &planpb.PlanEvent_Progress{Progress: &planpb.Progress{
+			Stage:      event.Stage,
+			Message:    event.Message,
+			Query:      event.Query,
+			Count:      int32(event.Count),
+			Round:      int32(event.Round),
+			Violations: toProtoViolations(event.Violations),
+		}}}
+	}
+}
+
+func toProtoTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
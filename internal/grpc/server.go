@@ -1,15 +1,21 @@
 package grpc
 
 import (
+	"ai-routes-service/internal/grpc/planpb"
 	"ai-routes-service/internal/models"
 	"ai-routes-service/internal/services"
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net"
 
 	"github.com/Semhumc/grpc-proto/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
 )
 
 type AIGrpcServer struct {
@@ -25,8 +31,51 @@ func NewAIGrpcServer(aiService *services.AIService) *AIGrpcServer {
 
 func (s *AIGrpcServer) GeneratePlan(ctx context.Context, req *proto.PromptRequest) (*proto.TripPlanResponse, error) {
 	log.Printf("📥 gRPC Request alındı: %+v", req)
-	
-	promptBody := models.PromptBody{
+
+	prompt, err := toPromptBody(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.AIService.GenerateTripPlan(ctx, prompt)
+	if err != nil {
+		log.Printf("❌ AI Service hatası: %v", err)
+		if errors.Is(err, services.ErrPlanUnavailable) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		if errors.Is(err, services.ErrInvalidPrompt) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr).Err()
+		}
+		return nil, err
+	}
+
+	// Proto mesajında durum/ihlal alanları olmadığı için response header'ında gönderiliyor
+	setPlanHeaders(ctx, result)
+	response := toProtoTripPlan(result.Plan)
+
+	log.Printf("🎯 Final gRPC response hazırlandı. Daily plans: %d", len(response.DailyPlan))
+	return response, nil
+}
+
+// İstek metadata'sında tercihlerin anahtarı; değer serileştirilmiş planpb.TripPreferences
+const preferencesMetadataKey = "x-trip-preferences-bin"
+
+// proto.PromptRequest'i ve varsa metadata'daki tercihleri PromptBody'ye çevirir.
+// Tercihlerin içeriği servis tarafında (NormalizePrompt) doğrulanır.
+func toPromptBody(ctx context.Context, req *proto.PromptRequest) (models.PromptBody, error) {
+	prompt := promptFields(req)
+	preferences, err := metadataPreferences(ctx)
+	if err != nil {
+		return prompt, err
+	}
+	prompt.Preferences = preferences
+	return prompt, nil
+}
+
+func promptFields(req *proto.PromptRequest) models.PromptBody {
+	return models.PromptBody{
 		UserID:        req.UserId,
 		Name:          req.Name,
 		Description:   req.Description,
@@ -35,136 +84,108 @@ func (s *AIGrpcServer) GeneratePlan(ctx context.Context, req *proto.PromptReques
 		StartDate:     req.StartDate,
 		EndDate:       req.EndDate,
 	}
+}
+
+// Metadata'da tercih yoksa nil döner
+func metadataPreferences(ctx context.Context) (*models.TripPreferences, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(preferencesMetadataKey)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	var preferences planpb.TripPreferences
+	if err := protobuf.Unmarshal([]byte(values[0]), &preferences); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid %s metadata: %v", preferencesMetadataKey, err)
+	}
+	return fromProtoPreferences(&preferences), nil
+}
 
-	result, err := s.AIService.GenerateTripPlan(promptBody)
-	if err != nil {
-		log.Printf("❌ AI Service hatası: %v", err)
-		return nil, err
+func fromProtoPreferences(preferences *planpb.TripPreferences) *models.TripPreferences {
+	return &models.TripPreferences{
+		Accommodation:   preferences.Accommodation,
+		GroupSize:       int(preferences.GroupSize),
+		Pets:            preferences.Pets,
+		BudgetPerNight:  preferences.BudgetPerNight,
+		Amenities:       preferences.Amenities,
+		MaxDailyDriveKm: preferences.MaxDailyDriveKm,
+	}
+}
+
+func toProtoPreferences(preferences *models.TripPreferences) *planpb.TripPreferences {
+	if preferences == nil {
+		return nil
+	}
+	return &planpb.TripPreferences{
+		Accommodation:   preferences.Accommodation,
+		GroupSize:       int32(preferences.GroupSize),
+		Pets:            preferences.Pets,
+		BudgetPerNight:  preferences.BudgetPerNight,
+		Amenities:       preferences.Amenities,
+		MaxDailyDriveKm: preferences.MaxDailyDriveKm,
 	}
+}
 
-	log.Printf("📤 AI Service sonucu: %s", result)
-
-	// AI Response struct'ını tanımla
-	type AIResponseLocation struct {
-		Name      string  `json:"name"`
-		Address   *string `json:"address"`
-		SiteURL   *string `json:"site_url"`
-		Latitude  float64 `json:"latitude"`
-		Longitude float64 `json:"longitude"`
-		Notes     *string `json:"notes"`
-	}
-
-	type AIResponseDailyPlan struct {
-		Day      int32              `json:"day"`
-		Date     string             `json:"date"`
-		Location AIResponseLocation `json:"location"`
-	}
-
-	type AIResponseTrip struct {
-		UserID        string `json:"user_id"`
-		Name          string `json:"name"`
-		Description   string `json:"description"`
-		StartPosition string `json:"start_position"`
-		EndPosition   string `json:"end_position"`
-		StartDate     string `json:"start_date"`
-		EndDate       string `json:"end_date"`
-		TotalDays     int32  `json:"total_days"`
-		RouteSummary  string `json:"route_summary"`
-	}
-
-	type AIResponse struct {
-		Trip      AIResponseTrip        `json:"trip"`
-		DailyPlan []AIResponseDailyPlan `json:"daily_plan"`
-	}
-
-	// JSON parse et
-	var aiResponse AIResponse
-	if err := json.Unmarshal([]byte(result), &aiResponse); err != nil {
-		log.Printf("❌ JSON parse hatası: %v", err)
-		log.Printf("🔧 Fallback response oluşturuluyor...")
-		
-		// Fallback response
-		return &proto.TripPlanResponse{
-			Trip: &proto.Trip{
-				UserId:        req.UserId,
-				Name:          req.Name,
-				Description:   req.Description,
-				StartPosition: req.StartPosition,
-				EndPosition:   req.EndPosition,
-				StartDate:     req.StartDate,
-				EndDate:       req.EndDate,
-				TotalDays:     7,
-				RouteSummary:  "Kamp rotası planlandı. Detaylar için sistem yöneticisi ile iletişime geçin.",
-			},
-			DailyPlan: []*proto.DailyPlan{
-				{
-					Day:  1,
-					Date: req.StartDate,
-					Location: &proto.Location{
-						Name:      "Kamp Alanı 1",
-						Address:   req.StartPosition + " yakını",
-						SiteUrl:   "",
-						Latitude:  39.0,
-						Longitude: 35.0,
-						Notes:     "Güzel kamp alanı",
-					},
-				},
-			},
-		}, nil
-	}
-
-	log.Printf("✅ JSON başarıyla parse edildi. Daily plans sayısı: %d", len(aiResponse.DailyPlan))
-
-	// Parsed response'u proto'ya çevir
+// TripPlan modelini proto response'una çevirir
+func toProtoTripPlan(plan *models.TripPlan) *proto.TripPlanResponse {
 	var dailyPlans []*proto.DailyPlan
-	for i, daily := range aiResponse.DailyPlan {
+	for _, daily := range plan.DailyPlan {
 		log.Printf("📍 Day %d: %s - %s", daily.Day, daily.Date, daily.Location.Name)
-		
-		dailyPlan := &proto.DailyPlan{
-			Day:  daily.Day,
-			Date: daily.Date,
-			Location: &proto.Location{
-				Name:      daily.Location.Name,
-				Address:   getStringValue(daily.Location.Address),
-				SiteUrl:   getStringValue(daily.Location.SiteURL),
-				Latitude:  daily.Location.Latitude,
-				Longitude: daily.Location.Longitude,
-				Notes:     getStringValue(daily.Location.Notes),
-			},
-		}
-		dailyPlans = append(dailyPlans, dailyPlan)
-		
-		log.Printf("✅ Daily plan %d eklendi", i+1)
+
+		dailyPlans = append(dailyPlans, toProtoDailyPlan(daily))
 	}
 
-	response := &proto.TripPlanResponse{
+	return &proto.TripPlanResponse{
 		Trip: &proto.Trip{
-			UserId:        aiResponse.Trip.UserID,
-			Name:          aiResponse.Trip.Name,
-			Description:   aiResponse.Trip.Description,
-			StartPosition: aiResponse.Trip.StartPosition,
-			EndPosition:   aiResponse.Trip.EndPosition,
-			StartDate:     aiResponse.Trip.StartDate,
-			EndDate:       aiResponse.Trip.EndDate,
-			TotalDays:     aiResponse.Trip.TotalDays,
-			RouteSummary:  aiResponse.Trip.RouteSummary,
+			UserId:        plan.Trip.UserID,
+			Name:          plan.Trip.Name,
+			Description:   plan.Trip.Description,
+			StartPosition: plan.Trip.StartPosition,
+			EndPosition:   plan.Trip.EndPosition,
+			StartDate:     plan.Trip.StartDate,
+			EndDate:       plan.Trip.EndDate,
+			TotalDays:     int32(plan.Trip.TotalDays),
+			RouteSummary:  plan.Trip.RouteSummary,
 		},
 		DailyPlan: dailyPlans,
 	}
+}
 
-	log.Printf("🎯 Final gRPC response hazırlandı. Daily plans: %d", len(response.DailyPlan))
-	return response, nil
+func toProtoDailyPlan(daily models.DailyPlan) *proto.DailyPlan {
+	return &proto.DailyPlan{
+		Day:  int32(daily.Day),
+		Date: daily.Date,
+		Location: &proto.Location{
+			Name:      daily.Location.Name,
+			Address:   daily.Location.Address,
+			SiteUrl:   daily.Location.SiteURL,
+			Latitude:  daily.Location.Latitude,
+			Longitude: daily.Location.Longitude,
+			Notes:     daily.Location.Notes,
+		},
+	}
 }
 
-// Helper function - pointer string'i normal string'e çevir
-func getStringValue(s *string) string {
-	if s == nil {
-		return ""
+// Plan kalite bilgilerini gRPC response metadata'sına ekler.
+// -bin anahtarları binary taşındığı için Türkçe mesajlar bozulmaz.
+func setPlanHeaders(ctx context.Context, result *models.PlanResult) {
+	violations, err := json.Marshal(result.Violations)
+	if err != nil {
+		log.Printf("⚠️ Violations encode hatası: %v", err)
+		return
+	}
+	md := metadata.Pairs(
+		"x-plan-status", result.Status,
+		"x-plan-violations-bin", string(violations),
+	)
+	if result.DegradedReason != "" {
+		md.Set("x-degraded-reason", result.DegradedReason)
+	}
+	if err := grpc.SetHeader(ctx, md); err != nil {
+		log.Printf("⚠️ gRPC header gönderilemedi: %v", err)
 	}
-	return *s
 }
 
-func StartGRPCServer(aiService *services.AIService, port string) {
+func StartGRPCServer(aiService *services.AIService, jobs *services.JobQueue, port string) {
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
@@ -174,9 +195,10 @@ func StartGRPCServer(aiService *services.AIService, port string) {
 
 	aiGrpcServer := NewAIGrpcServer(aiService)
 	proto.RegisterAIServiceServer(s, aiGrpcServer)
+	planpb.RegisterPlanServiceServer(s, NewPlanGrpcServer(aiService, jobs))
 
 	log.Printf("🚀 gRPC server listening on port %s", port)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,358 @@
+package grpc
+
+import (
+	"ai-routes-service/internal/grpc/planpb"
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/services"
+	"ai-routes-service/internal/storage"
+	"ai-routes-service/internal/utils"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Semhumc/grpc-proto/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// Prompt veya config değiştiğinde: go test ./internal/grpc -update
+var update = flag.Bool("update", false, "replay fixture'larını mevcut yanıtlarla yeniden kaydet")
+
+const testModel = "gemini-test"
+
+func replayProvider(t *testing.T, name string) services.Provider {
+	t.Helper()
+	dir := filepath.Join("testdata", "replay", name)
+	if !*update {
+		return services.NewReplayProvider(dir, testModel)
+	}
+	provider, err := services.RerecordFixtures(dir, testModel)
+	if err != nil {
+		t.Fatalf("rerecord %s: %v", name, err)
+	}
+	return provider
+}
+
+// Servisi bellek içi bir bağlantı üzerinden gerçek bir gRPC sunucusunda çalıştırır
+func startTestServer(t *testing.T, aiService *services.AIService) proto.AIServiceClient {
+	t.Helper()
+	conn := dialTestServer(t, func(s *grpc.Server) {
+		proto.RegisterAIServiceServer(s, NewAIGrpcServer(aiService))
+	})
+	return proto.NewAIServiceClient(conn)
+}
+
+func dialTestServer(t *testing.T, register func(s *grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestGeneratePlanMapping(t *testing.T) {
+	// Boş fixture dizini: tüm aramalar "sonuç bulunamadı" döner
+	search := utils.NewFixtureSearchProvider(t.TempDir())
+	aiService := &services.AIService{Provider: replayProvider(t, "generate_plan"), Search: search}
+	client := startTestServer(t, aiService)
+
+	var header metadata.MD
+	resp, err := client.GeneratePlan(context.Background(), &proto.PromptRequest{
+		UserId:        "u-1",
+		Name:          "Ege Turu",
+		Description:   "Sahil boyunca kamp",
+		StartPosition: "İzmir",
+		EndPosition:   "Antalya",
+		StartDate:     "2025-08-01",
+		EndDate:       "2025-08-04",
+	}, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+
+	if resp.Trip.TotalDays != 4 || resp.Trip.RouteSummary == "" {
+		t.Errorf("trip = %+v", resp.Trip)
+	}
+	if len(resp.DailyPlan) != 3 {
+		t.Fatalf("daily plans = %d, want 3", len(resp.DailyPlan))
+	}
+
+	day2 := resp.DailyPlan[1]
+	if day2.Day != 2 || day2.Date != "2025-08-02" {
+		t.Errorf("day 2 = %+v", day2)
+	}
+	if day2.Location.Name != "Kaş Camping" || day2.Location.Latitude != 36.201234 || day2.Location.Longitude != 29.631234 {
+		t.Errorf("day 2 location = %+v", day2.Location)
+	}
+	// null alanlar boş string'e dönmeli
+	if day2.Location.Notes != "" || resp.DailyPlan[2].Location.SiteUrl != "" {
+		t.Errorf("null fields should map to empty strings: %+v / %+v", day2.Location, resp.DailyPlan[2].Location)
+	}
+
+	if got := header.Get("x-plan-status"); len(got) != 1 || got[0] != "ok" {
+		t.Errorf("x-plan-status = %q, want ok", got)
+	}
+	if got := header.Get("x-plan-violations-bin"); len(got) != 1 || got[0] != "[]" {
+		t.Errorf("x-plan-violations-bin = %q, want empty list", got)
+	}
+}
+
+func TestGeneratePlanDegradedSignalling(t *testing.T) {
+	provider := services.NewFakeProvider(testModel)
+	provider.Err = errors.New("quota exceeded")
+	aiService := &services.AIService{Provider: provider, Search: utils.NewFixtureSearchProvider(t.TempDir())}
+	client := startTestServer(t, aiService)
+
+	var header metadata.MD
+	_, err := client.GeneratePlan(context.Background(), &proto.PromptRequest{StartDate: "2025-08-01", EndDate: "2025-08-02"}, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+	if got := header.Get("x-plan-status"); len(got) != 1 || got[0] != "degraded" {
+		t.Errorf("x-plan-status = %q, want degraded", got)
+	}
+	if got := header.Get("x-degraded-reason"); len(got) != 1 || got[0] != services.DegradedGenerationFailed {
+		t.Errorf("x-degraded-reason = %q", got)
+	}
+
+	aiService.StrictMode = true
+	_, err = client.GeneratePlan(context.Background(), &proto.PromptRequest{StartDate: "2025-08-01", EndDate: "2025-08-02"})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("strict mode error = %v, want Unavailable", err)
+	}
+}
+
+func TestPlanJobsSubmitAndGet(t *testing.T) {
+	provider := services.NewFakeProvider(testModel)
+	provider.Err = errors.New("quota exceeded")
+	aiService := &services.AIService{Provider: provider, Search: utils.NewFixtureSearchProvider(t.TempDir())}
+	jobs := services.NewJobQueue(aiService, 1, 10)
+	t.Cleanup(jobs.Shutdown)
+
+	conn := dialTestServer(t, func(s *grpc.Server) {
+		planpb.RegisterPlanServiceServer(s, NewPlanGrpcServer(aiService, jobs))
+	})
+	client := planpb.NewPlanServiceClient(conn)
+
+	submitted, err := client.SubmitPlan(context.Background(), &planpb.PlanRequest{Prompt: &proto.PromptRequest{StartDate: "2025-08-01", EndDate: "2025-08-02"}})
+	if err != nil {
+		t.Fatalf("SubmitPlan: %v", err)
+	}
+	if submitted.JobId == "" || submitted.Status != planpb.JobStatus_JOB_STATUS_QUEUED {
+		t.Fatalf("submitted = %+v", submitted)
+	}
+
+	var job *planpb.PlanJob
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		job, err = client.GetPlan(context.Background(), &planpb.GetPlanRequest{JobId: submitted.JobId})
+		if err != nil {
+			t.Fatalf("GetPlan: %v", err)
+		}
+		if job.Status == planpb.JobStatus_JOB_STATUS_DONE {
+			break
+		}
+	}
+	if job.Status != planpb.JobStatus_JOB_STATUS_DONE || job.Plan == nil || job.FinishedAt == nil {
+		t.Fatalf("job = %+v", job)
+	}
+	if job.PlanStatus != "degraded" || job.DegradedReason != services.DegradedGenerationFailed {
+		t.Errorf("plan status = %s (%s)", job.PlanStatus, job.DegradedReason)
+	}
+
+	_, err = client.GetPlan(context.Background(), &planpb.GetPlanRequest{JobId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetPlan(missing) err = %v, want NotFound", err)
+	}
+}
+
+func TestGetPlanFallsBackToStore(t *testing.T) {
+	store, err := storage.Open(storage.DriverSQLite, filepath.Join(t.TempDir(), "plans.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	finished := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+	record := &models.PlanRecord{ID: "p-1", UserID: "u-1", Version: 1, Model: testModel, StartedAt: finished.Add(-time.Minute), FinishedAt: finished,
+		Result: &models.PlanResult{Plan: &models.TripPlan{}, Status: models.PlanStatusOK}}
+	if err := store.SavePlan(context.Background(), record); err != nil {
+		t.Fatalf("SavePlan: %v", err)
+	}
+
+	aiService := &services.AIService{Provider: services.NewFakeProvider(testModel), Store: store}
+	jobs := services.NewJobQueue(aiService, 1, 1)
+	t.Cleanup(jobs.Shutdown)
+	conn := dialTestServer(t, func(s *grpc.Server) {
+		planpb.RegisterPlanServiceServer(s, NewPlanGrpcServer(aiService, jobs))
+	})
+	client := planpb.NewPlanServiceClient(conn)
+
+	// Kuyrukta olmayan (süresi dolmuş veya yeniden başlatmada kaybolmuş) job kayıttan gelir
+	job, err := client.GetPlan(context.Background(), &planpb.GetPlanRequest{JobId: "p-1"})
+	if err != nil {
+		t.Fatalf("GetPlan: %v", err)
+	}
+	if job.JobId != "p-1" || job.Status != planpb.JobStatus_JOB_STATUS_DONE || job.Plan == nil || !job.FinishedAt.AsTime().Equal(finished) {
+		t.Errorf("job = %+v", job)
+	}
+	if _, err := client.GetPlan(context.Background(), &planpb.GetPlanRequest{JobId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetPlan(missing) err = %v, want NotFound", err)
+	}
+}
+
+func TestStreamPlanEmitsDaysBeforeResult(t *testing.T) {
+	aiService := &services.AIService{Provider: replayProvider(t, "generate_plan"), Search: utils.NewFixtureSearchProvider(t.TempDir())}
+	conn := dialTestServer(t, func(s *grpc.Server) {
+		planpb.RegisterPlanServiceServer(s, NewPlanGrpcServer(aiService, nil))
+	})
+	client := planpb.NewPlanServiceClient(conn)
+
+	stream, err := client.StreamPlan(context.Background(), &planpb.PlanRequest{Prompt: &proto.PromptRequest{
+		UserId:        "u-1",
+		Name:          "Ege Turu",
+		Description:   "Sahil boyunca kamp",
+		StartPosition: "İzmir",
+		EndPosition:   "Antalya",
+		StartDate:     "2025-08-01",
+		EndDate:       "2025-08-04",
+	}})
+	if err != nil {
+		t.Fatalf("StreamPlan: %v", err)
+	}
+
+	var stages []string
+	var days []int32
+	var result *planpb.PlanResult
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if result != nil {
+			t.Fatalf("event after result: %+v", event)
+		}
+		switch e := event.Event.(type) {
+		case *planpb.PlanEvent_Progress:
+			stages = append(stages, e.Progress.Stage)
+		case *planpb.PlanEvent_Day:
+			days = append(days, e.Day.Day)
+		case *planpb.PlanEvent_Result:
+			result = e.Result
+		}
+	}
+
+	if len(stages) == 0 || stages[0] != "search_query" {
+		t.Errorf("progress stages = %v", stages)
+	}
+	if len(days) != 3 || days[0] != 1 || days[2] != 3 {
+		t.Errorf("days = %v, want 1..3", days)
+	}
+	if result == nil || result.Status != "ok" || len(result.Plan.DailyPlan) != 3 {
+		t.Fatalf("result = %+v", result)
+	}
+}
+
+func TestSubmitPlanPreferences(t *testing.T) {
+	aiService := &services.AIService{Provider: services.NewFakeProvider(testModel), Search: utils.NewFixtureSearchProvider(t.TempDir())}
+	jobs := services.NewJobQueue(aiService, 1, 10)
+	t.Cleanup(jobs.Shutdown)
+	conn := dialTestServer(t, func(s *grpc.Server) {
+		planpb.RegisterPlanServiceServer(s, NewPlanGrpcServer(aiService, jobs))
+	})
+	client := planpb.NewPlanServiceClient(conn)
+	req := &planpb.PlanRequest{Prompt: &proto.PromptRequest{StartDate: "2025-08-01", EndDate: "2025-08-02"}}
+
+	if _, err := client.SubmitPlan(context.Background(), &planpb.PlanRequest{Prompt: req.Prompt, Preferences: &planpb.TripPreferences{Accommodation: "tent", Pets: true}}); err != nil {
+		t.Fatalf("SubmitPlan with typed preferences: %v", err)
+	}
+	_, err := client.SubmitPlan(context.Background(), &planpb.PlanRequest{Prompt: req.Prompt, Preferences: &planpb.TripPreferences{GroupSize: -1}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SubmitPlan(negative group size) err = %v, want InvalidArgument", err)
+	}
+	if _, err := client.SubmitPlan(context.Background(), &planpb.PlanRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SubmitPlan(no prompt) err = %v, want InvalidArgument", err)
+	}
+
+	// Metadata sözleşmesi: PlanRequest.preferences boşsa serileştirilmiş TripPreferences okunur
+	withPreferences := func(p *planpb.TripPreferences) context.Context {
+		data, err := protobuf.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return metadata.AppendToOutgoingContext(context.Background(), preferencesMetadataKey, string(data))
+	}
+
+	if _, err := client.SubmitPlan(withPreferences(&planpb.TripPreferences{Accommodation: "Caravan", Amenities: []string{"Beach access"}}), req); err != nil {
+		t.Fatalf("SubmitPlan with valid preferences: %v", err)
+	}
+	_, err = client.SubmitPlan(withPreferences(&planpb.TripPreferences{Accommodation: "castle"}), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SubmitPlan(unknown accommodation) err = %v, want InvalidArgument", err)
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), preferencesMetadataKey, "\xff\xff")
+	if _, err := client.SubmitPlan(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SubmitPlan(garbage metadata) err = %v, want InvalidArgument", err)
+	}
+	if _, err := client.SubmitPlan(ctx, &planpb.PlanRequest{Prompt: req.Prompt, Preferences: &planpb.TripPreferences{}}); err != nil {
+		t.Errorf("SubmitPlan(garbage metadata, typed preferences) = %v, want metadata ignored", err)
+	}
+}
+
+func TestListPlansIncludesRevisionFields(t *testing.T) {
+	store, err := storage.Open(storage.DriverSQLite, filepath.Join(t.TempDir(), "plans.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	started := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+	for _, record := range []*models.PlanRecord{
+		{ID: "p-1", UserID: "u-1", Version: 1, StartedAt: started, FinishedAt: started},
+		{ID: "p-2", UserID: "u-1", ParentID: "p-1", Version: 2, Instruction: "2. günü değiştir", StartedAt: started.Add(time.Minute), FinishedAt: started.Add(time.Minute)},
+	} {
+		if err := store.SavePlan(context.Background(), record); err != nil {
+			t.Fatalf("SavePlan: %v", err)
+		}
+	}
+
+	aiService := &services.AIService{Provider: services.NewFakeProvider(testModel), Store: store}
+	conn := dialTestServer(t, func(s *grpc.Server) {
+		planpb.RegisterPlanServiceServer(s, NewPlanGrpcServer(aiService, nil))
+	})
+	resp, err := planpb.NewPlanServiceClient(conn).ListPlans(context.Background(), &planpb.ListPlansRequest{UserId: "u-1"})
+	if err != nil {
+		t.Fatalf("ListPlans: %v", err)
+	}
+	if len(resp.Plans) != 2 {
+		t.Fatalf("plans = %+v", resp.Plans)
+	}
+	revision, original := resp.Plans[0], resp.Plans[1]
+	if revision.Id != "p-2" || revision.ParentId != "p-1" || revision.Version != 2 || revision.Instruction != "2. günü değiştir" {
+		t.Errorf("revision = %+v", revision)
+	}
+	if original.Id != "p-1" || original.ParentId != "" || original.Version != 1 {
+		t.Errorf("original = %+v", original)
+	}
+}
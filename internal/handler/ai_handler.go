@@ -1,43 +1,308 @@
 package handler
 
 import (
+	"ai-routes-service/internal/export"
 	"ai-routes-service/internal/models"
 	"ai-routes-service/internal/services"
+	"ai-routes-service/internal/storage"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+var errPlanNotReady = errors.New("plan has no result yet")
+
 type AIHandler struct {
 	AIService *services.AIService
+	Jobs      *services.JobQueue
 }
 
 type AIHandlerInterface interface {
 	GenerateTripPlanHandler(c *fiber.Ctx) error
+	StreamTripPlanHandler(c *fiber.Ctx) error
+	SubmitPlanJobHandler(c *fiber.Ctx) error
+	GetPlanJobHandler(c *fiber.Ctx) error
+	ListUserPlansHandler(c *fiber.Ctx) error
+	RevisePlanHandler(c *fiber.Ctx) error
+	RegenerateDayHandler(c *fiber.Ctx) error
+	ExportPlanHandler(c *fiber.Ctx) error
 }
 
-func NewAIHandler(aiService *services.AIService) *AIHandler {
+func NewAIHandler(aiService *services.AIService, jobs *services.JobQueue) *AIHandler {
 	return &AIHandler{
 		AIService: aiService,
+		Jobs:      jobs,
 	}
 }
 
 func (h *AIHandler) GenerateTripPlanHandler(c *fiber.Ctx) error {
 	log.Printf("📥 AI Handler: Request alındı")
-	
+
 	req := c.Locals("req").(models.ReqBody)
 	log.Printf("📋 AI Handler: Prompt data: %+v", req.Prompt)
 
-	output, err := h.AIService.GenerateTripPlan(req.Prompt)
+	// İstemci bağlantıyı kapatırsa veya sunucu kapanırsa üretim iptal edilir
+	ctx, cancel := clientContext(c)
+	defer cancel()
+	result, err := h.AIService.GenerateTripPlan(ctx, req.Prompt)
 	if err != nil {
 		log.Printf("❌ AI Handler: Service hatası: %v", err)
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, services.ErrPlanUnavailable) {
+			status = fiber.StatusBadGateway
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	log.Printf("✅ AI Handler: Başarılı response, %d gün, %d ihlal", len(result.Plan.DailyPlan), len(result.Violations))
+	return c.JSON(fiber.Map{
+		"result":          result.Plan,
+		"status":          result.Status,
+		"degraded_reason": result.DegradedReason,
+		"violations":      result.Violations,
+		"corrections":     result.Corrections,
+		"optimization":    result.Optimization,
+	})
+}
+
+// Plan üretimini server-sent events olarak yayınlar: arama sorguları, model
+// çağrısı, model ürettikçe günlük planlar, doğrulama ve son olarak plan.
+// İstemci bağlantıyı kapatırsa üretim iptal edilir.
+func (h *AIHandler) StreamTripPlanHandler(c *fiber.Ctx) error {
+	req := c.Locals("req").(models.ReqBody)
+	log.Printf("📡 AI Handler: Stream başlatıldı: %+v", req.Prompt)
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// Body writer handler döndükten sonra çalışır, fiber.Ctx burada kullanılmamalı
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := make(chan models.ProgressEvent, 16)
+		send := func(event models.ProgressEvent) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		}
+
+		go func() {
+			defer close(events)
+			_, err := h.AIService.GenerateTripPlan(services.WithProgress(ctx, send), req.Prompt)
+			if err != nil {
+				log.Printf("❌ AI Handler: Stream hatası: %v", err)
+				send(models.ProgressEvent{Stage: models.ProgressError, Message: err.Error()})
+			}
+		}()
+
+		for event := range events {
+			if ctx.Err() != nil {
+				continue
+			}
+			if err := writeSSE(w, event); err != nil {
+				log.Printf("🛑 AI Handler: İstemci bağlantısı kapandı: %v", err)
+				cancel()
+			}
+		}
+	})
+	return nil
+}
+
+func writeSSE(w *bufio.Writer, event models.ProgressEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Stage, data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// Planı kuyruğa alır ve job ID'yi hemen döndürür
+func (h *AIHandler) SubmitPlanJobHandler(c *fiber.Ctx) error {
+	req := c.Locals("req").(models.ReqBody)
+
+	job, err := h.Jobs.Submit(req.Prompt)
+	if err != nil {
+		log.Printf("❌ AI Handler: Job kuyruğa alınamadı: %v", err)
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, services.ErrJobQueueFull) {
+			status = fiber.StatusServiceUnavailable
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	c.Location("/api/v1/plans/" + job.ID)
+	return c.Status(fiber.StatusAccepted).JSON(job)
+}
+
+// Job durumunu, tamamlandıysa planla birlikte döndürür. Kuyrukta olmayan ID'ler
+// (revizyonlar, süresi dolmuş veya yeniden başlatmada kaybolmuş job'lar) kayıtlı
+// plandan aynı job şekliyle döndürülür.
+func (h *AIHandler) GetPlanJobHandler(c *fiber.Ctx) error {
+	job, err := h.Jobs.Lookup(c.Context(), h.AIService.Store, c.Params("id"))
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, services.ErrJobNotFound) {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(job)
+}
+
+// Kullanıcının kaydedilmiş planlarını yeniden eskiye döndürür (?limit=&offset=)
+func (h *AIHandler) ListUserPlansHandler(c *fiber.Ctx) error {
+	if h.AIService.Store == nil {
+		return c.Status(fiber.StatusNotImplemented).JSON(fiber.Map{
+			"error": "plan storage is disabled",
+		})
+	}
+
+	plans, err := h.AIService.Store.ListPlans(c.Context(), c.Params("id"), c.QueryInt("limit"), c.QueryInt("offset"))
+	if err != nil {
+		log.Printf("❌ AI Handler: Plan listesi alınamadı: %v", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	log.Printf("✅ AI Handler: Başarılı response")
 	return c.JSON(fiber.Map{
-		"result": output,
+		"plans": plans,
 	})
-}
\ No newline at end of file
+}
+
+// Kayıtlı planı serbest metin talimatıyla revize eder, yeni sürümü ve değişen günleri döndürür
+func (h *AIHandler) RevisePlanHandler(c *fiber.Ctx) error {
+	var body models.ReviseBody
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	ctx, cancel := clientContext(c)
+	defer cancel()
+	revision, err := h.AIService.RevisePlan(ctx, c.Params("id"), body.Instruction)
+	if err != nil {
+		return revisionError(c, err)
+	}
+
+	log.Printf("✅ AI Handler: Plan revize edildi: %s → %s (%d değişiklik)", revision.ParentID, revision.Result.ID, len(revision.Changes))
+	return c.JSON(revision)
+}
+
+// Planın tek bir gününü komşu günleri koruyarak yeniden üretir. Body isteğe bağlıdır: {"instruction": "..."}
+func (h *AIHandler) RegenerateDayHandler(c *fiber.Ctx) error {
+	day, err := c.ParamsInt("day")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid day",
+		})
+	}
+	var body models.ReviseBody
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	ctx, cancel := clientContext(c)
+	defer cancel()
+	revision, err := h.AIService.RegenerateDay(ctx, c.Params("id"), day, body.Instruction)
+	if err != nil {
+		return revisionError(c, err)
+	}
+
+	log.Printf("✅ AI Handler: Gün %d yeniden üretildi: %s → %s", day, revision.ParentID, revision.Result.ID)
+	return c.JSON(revision)
+}
+
+// Planı GPX, KML veya GeoJSON olarak indirir (/plans/:id.:format)
+func (h *AIHandler) ExportPlanHandler(c *fiber.Ctx) error {
+	plan, err := h.findPlan(c.Context(), c.Params("id"))
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, storage.ErrPlanNotFound) || errors.Is(err, services.ErrJobNotFound) || errors.Is(err, errPlanNotReady) {
+			status = fiber.StatusNotFound
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	format := c.Params("format")
+	data, contentType, err := export.Render(format, plan)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, export.ErrUnknownFormat) {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", c.Params("id")+"."+format))
+	return c.Send(data)
+}
+
+// Kayıtlı planı, depolama kapalıysa veya plan henüz kaydedilmediyse tamamlanmış job sonucunu döndürür
+func (h *AIHandler) findPlan(ctx context.Context, id string) (*models.TripPlan, error) {
+	if h.AIService.Store != nil {
+		record, err := h.AIService.Store.GetPlan(ctx, id)
+		switch {
+		case err == nil && record.Result != nil && record.Result.Plan != nil:
+			return record.Result.Plan, nil
+		case err != nil && !errors.Is(err, storage.ErrPlanNotFound):
+			return nil, err
+		}
+	}
+
+	job, err := h.Jobs.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Result == nil || job.Result.Plan == nil {
+		return nil, errPlanNotReady
+	}
+	return job.Result.Plan, nil
+}
+
+func revisionError(c *fiber.Ctx, err error) error {
+	log.Printf("❌ AI Handler: Revizyon hatası: %v", err)
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrEmptyInstruction), errors.Is(err, services.ErrPlanNotRevisable), errors.Is(err, services.ErrDayOutOfRange):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, storage.ErrPlanNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, services.ErrStorageDisabled):
+		status = fiber.StatusNotImplemented
+	case errors.Is(err, services.ErrPlanUnavailable):
+		status = fiber.StatusBadGateway
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/services"
+	"ai-routes-service/internal/storage"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestGetPlanFallsBackToStoredRevision(t *testing.T) {
+	store, err := storage.Open(storage.DriverSQLite, filepath.Join(t.TempDir(), "plans.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer store.Close()
+	now := time.Now()
+	revision := &models.PlanRecord{ID: "p-2", UserID: "u-1", ParentID: "p-1", Version: 2, Instruction: "2. günü değiştir",
+		Model: "gemini-test", Result: &models.PlanResult{Plan: &models.TripPlan{}, Status: models.PlanStatusOK}, StartedAt: now, FinishedAt: now}
+	if err := store.SavePlan(context.Background(), revision); err != nil {
+		t.Fatalf("SavePlan: %v", err)
+	}
+
+	aiService := &services.AIService{Provider: services.NewFakeProvider("gemini-test"), Store: store}
+	jobs := services.NewJobQueue(aiService, 1, 1)
+	defer jobs.Shutdown()
+	h := NewAIHandler(aiService, jobs)
+	app := fiber.New()
+	app.Get("/plans/:id", h.GetPlanJobHandler)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/plans/p-2", nil))
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	// Kuyruktaki job'larla aynı şekil
+	var got models.PlanJob
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != "p-2" || got.Status != models.JobStatusDone || got.Result == nil || got.FinishedAt == nil {
+		t.Errorf("job = %+v", got)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/plans/missing", nil))
+	if err != nil || resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("GET missing = %v, %v; want 404", resp.StatusCode, err)
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// İstemci bağlantısının kapanıp kapanmadığını kontrol etme aralığı
+const DISCONNECT_POLL_INTERVAL = 500 * time.Millisecond
+
+// clientContext istemci bağlantıyı kapattığında veya sunucu kapanırken iptal edilen
+// bir context döndürür. fasthttp RequestCtx'in Done'ı sadece sunucu kapanırken
+// tetiklendiği için bağlantı periyodik olarak okunmadan (peek) kontrol edilir.
+// Bağlantı kontrol edilemiyorsa (TLS veya desteklenmeyen platform) sadece sunucu kapanışı geçerlidir.
+// Dönen cancel handler'dan önce çağrılmalıdır.
+func clientContext(c *fiber.Ctx) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(c.Context())
+	conn := c.Context().Conn()
+	closed, ok := peerClosed(conn)
+	if !ok {
+		return ctx, cancel
+	}
+	if closed {
+		cancel()
+		return ctx, cancel
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(DISCONNECT_POLL_INTERVAL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				if closed, _ := peerClosed(conn); closed {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return ctx, func() {
+		close(done)
+		cancel()
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package handler
+
+import "net"
+
+// Bu platformlarda bağlantı okunmadan kontrol edilemez
+func peerClosed(conn net.Conn) (closed bool, ok bool) {
+	return false, false
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestClientContextCanceledOnDisconnect(t *testing.T) {
+	ctxErr := make(chan error, 1)
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		ctx, cancel := clientContext(c)
+		defer cancel()
+		select {
+		case <-ctx.Done():
+			ctxErr <- ctx.Err()
+		case <-time.After(5 * time.Second):
+			ctxErr <- errors.New("not canceled")
+		}
+		return nil
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go app.Listener(ln)
+	defer app.Shutdown()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.Write([]byte("GET /slow HTTP/1.1\r\nHost: test\r\n\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	conn.Close()
+
+	if err := <-ctxErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("handler ctx error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,41 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package handler
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// peerClosed soket okuma kuyruğunu tüketmeden (MSG_PEEK) karşı tarafın bağlantıyı
+// kapatıp kapatmadığına bakar. Bekleyen veri (pipelined istek) bağlantıyı açık sayar.
+// ok, bağlantının kontrol edilebilir olup olmadığını belirtir.
+func peerClosed(conn net.Conn) (closed bool, ok bool) {
+	sc, isSyscallConn := conn.(syscall.Conn)
+	if !isSyscallConn {
+		return false, false
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return false, false
+	}
+
+	buf := make([]byte, 1)
+	var n int
+	var peekErr error
+	if err := raw.Read(func(fd uintptr) bool {
+		n, _, peekErr = syscall.Recvfrom(int(fd), buf, syscall.MSG_PEEK|syscall.MSG_DONTWAIT)
+		return true
+	}); err != nil {
+		return true, true
+	}
+	switch {
+	case peekErr == nil:
+		return n == 0, true
+	case errors.Is(peekErr, syscall.EAGAIN), errors.Is(peekErr, syscall.EWOULDBLOCK), errors.Is(peekErr, syscall.EINTR):
+		return false, true
+	default:
+		return true, true
+	}
+}
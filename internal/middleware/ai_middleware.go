@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/services"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -16,6 +17,15 @@ func AIMiddleware(c *fiber.Ctx) error {
 		})
 	}
 
+	// Tarihler doğrulanıp YYYY-MM-DD formatına çevrilir
+	prompt, err := services.NormalizePrompt(req.Prompt)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	req.Prompt = prompt
+
 	c.Locals("req", req)
 
 	return c.Next()
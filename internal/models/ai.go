@@ -8,8 +8,45 @@ type PromptBody struct {
 	EndPosition   string `json:"end_position"`
 	StartDate     string `json:"start_date"`
 	EndDate       string `json:"end_date"`
+	// İsteğe bağlı yapılandırılmış tercihler; verilmezse yalnızca Description kullanılır
+	Preferences *TripPreferences `json:"preferences,omitempty"`
+}
+
+// Konaklama tipleri
+const (
+	AccommodationTent     = "tent"
+	AccommodationCaravan  = "caravan"
+	AccommodationBungalow = "bungalow"
+	AccommodationGlamping = "glamping"
+)
+
+// Zorunlu tutulabilecek olanaklar
+const (
+	AmenityElectricity   = "electricity"
+	AmenityShowers       = "showers"
+	AmenityToilets       = "toilets"
+	AmenityDrinkingWater = "drinking_water"
+	AmenityWifi          = "wifi"
+	AmenityBeachAccess   = "beach_access"
+)
+
+// TripPreferences plana uygulanacak yapılandırılmış tercihler. Sıfır değerli
+// alanlar "tercih yok" anlamına gelir.
+type TripPreferences struct {
+	Accommodation string `json:"accommodation,omitempty"`
+	GroupSize     int    `json:"group_size,omitempty"`
+	Pets          bool   `json:"pets,omitempty"`
+	// Gecelik en fazla bütçe (TL)
+	BudgetPerNight float64  `json:"budget_per_night,omitempty"`
+	Amenities      []string `json:"amenities,omitempty"`
+	// Günlük en fazla sürüş mesafesi (km); sunucudaki DAILY_MAX_KM'den büyükse o geçerlidir
+	MaxDailyDriveKm float64 `json:"max_daily_drive_km,omitempty"`
 }
 
 type ReqBody struct {
 	Prompt PromptBody `json:"prompt"`
 }
+
+type ReviseBody struct {
+	Instruction string `json:"instruction"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Campsite yerel kamp alanı kataloğundaki bir kayıt
+type Campsite struct {
+	// Kaynağa göre kararlı ID, örn. "osm:node/123"; boşsa isim ve konumdan türetilir
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Address   string   `json:"address"`
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	SiteURL   string   `json:"site_url"`
+	Amenities []string `json:"amenities"`
+	// Serbest metin tanım (ortam, manzara vb.); anlamsal aramada kullanılır
+	Description string `json:"description"`
+	// Açık olduğu dönem, örn. "Mayıs-Ekim" veya "tüm yıl"
+	Season string `json:"season"`
+	Source string `json:"source"`
+	// Bilgileri elle veya resmi kaynaktan doğrulanmış kayıt
+	Verified  bool      `json:"verified"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
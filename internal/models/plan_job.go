@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Asenkron plan job durumları
+const (
+	JobStatusQueued  = "queued"
+	JobStatusRunning = "running"
+	JobStatusDone    = "done"
+	JobStatusFailed  = "failed"
+)
+
+// PlanJob kuyruğa alınmış bir plan üretim isteği
+type PlanJob struct {
+	ID         string      `json:"id"`
+	Status     string      `json:"status"`
+	Prompt     PromptBody  `json:"prompt"`
+	Result     *PlanResult `json:"result,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	StartedAt  *time.Time  `json:"started_at,omitempty"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+}
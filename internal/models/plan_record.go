@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// PlanRecord kaydedilmiş bir plan isteği ve sonucu
+type PlanRecord struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	// Revizyonlarda önceki sürümün ID'si ve revizyon talimatı
+	ParentID    string      `json:"parent_id,omitempty"`
+	Version     int         `json:"version"`
+	Instruction string      `json:"instruction,omitempty"`
+	Prompt      PromptBody  `json:"prompt"`
+	Result      *PlanResult `json:"result,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	Model       string      `json:"model"`
+	StartedAt   time.Time   `json:"started_at"`
+	FinishedAt  time.Time   `json:"finished_at"`
+	DurationMs  int64       `json:"duration_ms"`
+}
+
+// Gün farkı türleri
+const (
+	DayAdded   = "added"
+	DayRemoved = "removed"
+	DayChanged = "changed"
+)
+
+// DayChange iki plan sürümü arasında değişen tek bir gün
+type DayChange struct {
+	Day    int        `json:"day"`
+	Change string     `json:"change"`
+	Before *DailyPlan `json:"before,omitempty"`
+	After  *DailyPlan `json:"after,omitempty"`
+}
+
+// PlanRevision revize edilen planın yeni sürümü ve öncekine göre farkı
+type PlanRevision struct {
+	ParentID string      `json:"parent_id"`
+	Version  int         `json:"version"`
+	Result   *PlanResult `json:"result"`
+	Changes  []DayChange `json:"changes"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// Plan üretimi sırasında yayınlanan ilerleme aşamaları
+const (
+	ProgressSearchQuery       = "search_query"
+	ProgressSearchResults     = "search_results"
+	ProgressGenerationStarted = "generation_started"
+	ProgressPartialDay        = "partial_day"
+	ProgressValidation        = "validation"
+	ProgressRepair            = "repair"
+	ProgressPlan              = "plan"
+	ProgressError             = "error"
+)
+
+// ProgressEvent plan üretim hattındaki tek bir adım.
+// Aşamaya göre sadece ilgili alanlar doludur.
+type ProgressEvent struct {
+	Stage      string          `json:"stage"`
+	Message    string          `json:"message,omitempty"`
+	Query      string          `json:"query,omitempty"`
+	Count      int             `json:"count"`
+	Round      int             `json:"round,omitempty"`
+	Day        *DailyPlan      `json:"day,omitempty"`
+	Violations []PlanViolation `json:"violations,omitempty"`
+	Result     *PlanResult     `json:"result,omitempty"`
+}
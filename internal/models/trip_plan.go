@@ -0,0 +1,110 @@
+package models
+
+// TripPlan AI'ın ürettiği kamp rotası planı. Servis bu tipi döndürür,
+// HTTP handler doğrudan JSON'a çevirir, gRPC katmanı proto mesajlarına dönüştürür.
+// Tag'ler Gemini response şemasını da belirler (services.schemaFor).
+type TripPlan struct {
+	Trip      Trip        `json:"trip"`
+	DailyPlan []DailyPlan `json:"daily_plan" description:"Her gece için bir kayıt, gün sırasına göre"`
+}
+
+type Trip struct {
+	UserID        string `json:"user_id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	StartPosition string `json:"start_position"`
+	EndPosition   string `json:"end_position"`
+	StartDate     string `json:"start_date" description:"YYYY-MM-DD"`
+	EndDate       string `json:"end_date" description:"YYYY-MM-DD"`
+	// Bitiş dahil gün sayısı, tarihlerden sunucuda hesaplanır; daily_plan'da gece sayısı (TotalDays-1) kadar kayıt olur
+	TotalDays    int    `json:"total_days" schema:"-"`
+	RouteSummary string `json:"route_summary" description:"Güzergahın kısa özeti"`
+	// Başlangıç/bitiş konumlarının geocoder ile bulunan koordinatları; modelden istenmez
+	StartCoordinates *Coordinates `json:"start_coordinates,omitempty" schema:"-"`
+	EndCoordinates   *Coordinates `json:"end_coordinates,omitempty" schema:"-"`
+	// Son konaktan bitişe ve toplam mesafe (km); sunucuda hesaplanır
+	FinalLegKm      float64 `json:"final_leg_km,omitempty" schema:"-"`
+	TotalDistanceKm float64 `json:"total_distance_km,omitempty" schema:"-"`
+}
+
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// DailyPlan bir günün konaklama planı
+type DailyPlan struct {
+	Day      int      `json:"day" description:"1'den başlayan gün numarası"`
+	Date     string   `json:"date" description:"YYYY-MM-DD"`
+	Location Location `json:"location"`
+	// Önceki konaktan (ilk gün başlangıçtan) bu konağa mesafe; sunucuda hesaplanır
+	DistanceKm float64 `json:"distance_km" schema:"-"`
+}
+
+// Location kamp alanı bilgileri; model null döndürdüğünde string alanlar boş kalır
+type Location struct {
+	Name      string  `json:"name" description:"Gerçek kamp alanının adı"`
+	Address   string  `json:"address" description:"Mahalle, cadde, ilçe/il"`
+	SiteURL   string  `json:"site_url" description:"Resmi web sitesi, bilinmiyorsa boş"`
+	Latitude  float64 `json:"latitude" description:"6 ondalık hassasiyetli enlem"`
+	Longitude float64 `json:"longitude" description:"6 ondalık hassasiyetli boylam"`
+	Notes     string  `json:"notes" description:"Sezon, rezervasyon, ulaşım notları"`
+	// Tercih kontrolü için modelin bildirdiği alan bilgileri; bilinmiyorsa boş
+	Accommodation []string `json:"accommodation,omitempty" description:"Sunulan konaklama tipleri: tent, caravan, bungalow, glamping"`
+	Amenities     []string `json:"amenities,omitempty" description:"Olanaklar: electricity, showers, toilets, drinking_water, wifi, beach_access"`
+	PetsAllowed   *bool    `json:"pets_allowed,omitempty" description:"Evcil hayvan kabul ediliyor mu"`
+	PricePerNight float64  `json:"price_per_night,omitempty" description:"Gecelik yaklaşık ücret (TL), bilinmiyorsa 0"`
+	MaxGroupSize  int      `json:"max_group_size,omitempty" description:"Tek rezervasyonda kabul edilen en fazla kişi, bilinmiyorsa 0"`
+	// site_url kontrol sonucu: ok, dead, title_mismatch veya dropped; sunucuda hesaplanır
+	SiteStatus string `json:"site_status,omitempty" schema:"-"`
+}
+
+// PlanViolation planda tespit edilen tek bir kural ihlali
+type PlanViolation struct {
+	Code    string `json:"code"`
+	Day     int    `json:"day,omitempty"`
+	Message string `json:"message"`
+}
+
+// Plan durumları: degraded, modelden gerçek plan alınamadığında üretilen sentetik plandır
+const (
+	PlanStatusOK       = "ok"
+	PlanStatusDegraded = "degraded"
+)
+
+// PlanResult servisin döndürdüğü plan, plan durumu ve düzeltme turlarından sonra kalan ihlaller
+type PlanResult struct {
+	// Plan kaydedildiyse kayıt ID'si
+	ID             string          `json:"id,omitempty"`
+	Plan           *TripPlan       `json:"plan"`
+	Status         string          `json:"status"`
+	DegradedReason string          `json:"degraded_reason,omitempty"`
+	Violations     []PlanViolation `json:"violations"`
+	// Geocoder ile düzeltilen koordinatlar
+	Corrections []CoordinateCorrection `json:"corrections,omitempty"`
+	// Konak sırası optimizasyonunun sonucu
+	Optimization *RouteOptimization `json:"optimization,omitempty"`
+}
+
+// RouteOptimization modelin konak sırası ile toplam mesafeyi kısaltan sıranın karşılaştırması
+type RouteOptimization struct {
+	// Yeni sıradaki konakların modeldeki gün numaraları
+	Order          []int   `json:"order"`
+	OriginalKm     float64 `json:"original_km"`
+	OptimizedKm    float64 `json:"optimized_km"`
+	ImprovementKm  float64 `json:"improvement_km"`
+	ImprovementPct float64 `json:"improvement_pct"`
+	// Kazanç eşiğin altındaysa model sırası korunur
+	Applied bool `json:"applied"`
+}
+
+// CoordinateCorrection modelin verdiği koordinatın geocoder sonucuyla değiştirilmesi
+type CoordinateCorrection struct {
+	Day   int         `json:"day"`
+	Name  string      `json:"name"`
+	Query string      `json:"query"`
+	From  Coordinates `json:"from"`
+	To    Coordinates `json:"to"`
+	// Eski ve yeni koordinat arası mesafe; model koordinat vermediyse 0
+	DistanceKm float64 `json:"distance_km"`
+}
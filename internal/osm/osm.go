@@ -0,0 +1,126 @@
+package osm
+
+import (
+	"ai-routes-service/internal/models"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// OpenStreetMap extract'larından (PBF veya Overpass JSON) kamp alanlarını okur.
+// Yalnızca tourism=camp_site ve tourism=caravan_site etiketli node ve way'ler alınır;
+// way'lerin konumu düğümlerinin ortalamasıdır.
+
+// Element tek bir OSM node'u veya way'i
+type Element struct {
+	Type string // "node" veya "way"
+	ID   int64
+	Lat  float64
+	Lon  float64
+	Tags map[string]string
+}
+
+// IsCampsite etiketlerin kamp veya karavan alanı olup olmadığını söyler
+func IsCampsite(tags map[string]string) bool {
+	switch tags["tourism"] {
+	case "camp_site", "caravan_site":
+		return true
+	}
+	return false
+}
+
+// OSM etiketi → katalog olanağı
+var amenityTags = []struct {
+	key, name string
+}{
+	{"tents", "çadır"},
+	{"caravans", "karavan"},
+	{"power_supply", "elektrik"},
+	{"drinking_water", "içme suyu"},
+	{"shower", "duş"},
+	{"toilets", "tuvalet"},
+	{"internet_access", "wifi"},
+	{"sanitary_dump_station", "atık boşaltma"},
+	{"swimming_pool", "havuz"},
+	{"dog", "evcil hayvan"},
+}
+
+// Campsite elementi katalog kaydına çevirir; isimsiz veya konumsuz elementler atlanır
+func Campsite(el Element) (models.Campsite, bool) {
+	name := firstTag(el.Tags, "name", "name:tr", "name:en", "operator")
+	if name == "" || (el.Lat == 0 && el.Lon == 0) {
+		return models.Campsite{}, false
+	}
+
+	site := models.Campsite{
+		ID:          fmt.Sprintf("osm:%s/%d", el.Type, el.ID),
+		Name:        name,
+		Address:     address(el.Tags),
+		Latitude:    round6(el.Lat),
+		Longitude:   round6(el.Lon),
+		SiteURL:     website(firstTag(el.Tags, "website", "contact:website", "url")),
+		Description: firstTag(el.Tags, "description", "description:tr", "description:en"),
+		Season:      firstTag(el.Tags, "opening_hours", "seasonal"),
+		Source:      "osm",
+		Amenities:   []string{},
+	}
+	for _, tag := range amenityTags {
+		if value := el.Tags[tag.key]; value != "" && value != "no" {
+			site.Amenities = append(site.Amenities, tag.name)
+		}
+	}
+	if el.Tags["tourism"] == "caravan_site" && el.Tags["caravans"] == "" {
+		site.Amenities = append(site.Amenities, "karavan")
+	}
+	return site, true
+}
+
+func firstTag(tags map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := clean(tags[key]); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Birden fazla boşluğu teke indirir
+func clean(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// addr:* etiketlerinden "sokak no, ilçe, il posta kodu" biçiminde adres
+func address(tags map[string]string) string {
+	if full := clean(tags["addr:full"]); full != "" {
+		return full
+	}
+	parts := []string{}
+	street := strings.TrimSpace(clean(tags["addr:street"]) + " " + clean(tags["addr:housenumber"]))
+	for _, part := range []string{street, firstTag(tags, "addr:suburb", "addr:village", "addr:district"), firstTag(tags, "addr:city", "addr:province")} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	result := strings.Join(parts, ", ")
+	if postcode := clean(tags["addr:postcode"]); postcode != "" {
+		result = strings.TrimSpace(result + " " + postcode)
+	}
+	return result
+}
+
+// Şemasız adreslere https eklenir; birden fazla adres varsa ilki alınır
+func website(url string) string {
+	url, _, _ = strings.Cut(url, ";")
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return ""
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = "https://" + url
+	}
+	return url
+}
+
+func round6(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
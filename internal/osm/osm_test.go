@@ -0,0 +1,187 @@
+package osm
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestCampsite(t *testing.T) {
+	site, ok := Campsite(Element{Type: "way", ID: 42, Lat: 36.2012345678, Lon: 29.63, Tags: map[string]string{
+		"tourism": "caravan_site", "name": "  Kaş   Camping ", "website": "www.kaskamping.com; https://other",
+		"addr:street": "Hastane Cad.", "addr:housenumber": "3", "addr:district": "Kaş", "addr:province": "Antalya", "addr:postcode": "07580",
+		"shower": "yes", "power_supply": "no", "dog": "leashed",
+	}})
+	if !ok {
+		t.Fatal("Campsite = false, want a campsite")
+	}
+	if site.ID != "osm:way/42" || site.Name != "Kaş Camping" || site.Latitude != 36.201235 {
+		t.Errorf("site = %+v", site)
+	}
+	if site.Address != "Hastane Cad. 3, Kaş, Antalya 07580" {
+		t.Errorf("address = %q", site.Address)
+	}
+	if site.SiteURL != "https://www.kaskamping.com" {
+		t.Errorf("site url = %q", site.SiteURL)
+	}
+	if strings.Join(site.Amenities, ",") != "duş,evcil hayvan,karavan" {
+		t.Errorf("amenities = %v", site.Amenities)
+	}
+
+	if _, ok := Campsite(Element{Type: "node", ID: 1, Lat: 36, Lon: 29, Tags: map[string]string{"tourism": "camp_site"}}); ok {
+		t.Error("unnamed element imported")
+	}
+}
+
+func TestReadOverpass(t *testing.T) {
+	data := `{"elements": [
+		{"type": "node", "id": 1, "lat": 36.5, "lon": 30.5, "tags": {"tourism": "camp_site", "name": "Olympos"}},
+		{"type": "node", "id": 2, "lat": 40.0, "lon": 41.0, "tags": {"tourism": "hotel", "name": "Otel"}},
+		{"type": "way", "id": 3, "center": {"lat": 37.1, "lon": 27.2}, "tags": {"tourism": "camp_site", "name": "Bodrum"}},
+		{"type": "way", "id": 4, "nodes": [10, 11, 12, 10], "tags": {"tourism": "caravan_site", "name": "Ayder"}},
+		{"type": "node", "id": 10, "lat": 40.0, "lon": 41.0},
+		{"type": "node", "id": 11, "lat": 40.2, "lon": 41.0},
+		{"type": "node", "id": 12, "lat": 40.1, "lon": 41.3}
+	]}`
+	elements, err := ReadOverpass(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadOverpass: %v", err)
+	}
+	if len(elements) != 3 {
+		t.Fatalf("elements = %+v, want 3", elements)
+	}
+	if elements[1].Lat != 37.1 || elements[1].Lon != 27.2 {
+		t.Errorf("center way = %+v", elements[1])
+	}
+	if !near(elements[2].Lat, 40.1) || !near(elements[2].Lon, 41.1) {
+		t.Errorf("node way = %+v, want centroid 40.1, 41.1", elements[2])
+	}
+}
+
+func TestReadPBF(t *testing.T) {
+	// String tablosu: 0 boş, 1 tourism, 2 camp_site, 3 name, 4 Olympos, 5 Ayder, 6 highway, 7 track
+	table := []string{"", "tourism", "camp_site", "name", "Olympos", "Ayder", "highway", "track"}
+	var st []byte
+	for _, s := range table {
+		st = protowire.AppendTag(st, 1, protowire.BytesType)
+		st = protowire.AppendString(st, s)
+	}
+
+	// Dense: 10 (kamp alanı), 11, 12 (way düğümleri); granularity 100 → değerler 1e-7 derece
+	dense := packedField(nil, 1, zigzag(10, 1, 1))
+	dense = packedField(dense, 8, zigzag(365000000, 35000000, -1000000))
+	dense = packedField(dense, 9, zigzag(305000000, 105000000, 3000000))
+	dense = packedField(dense, 10, []uint64{1, 2, 3, 4, 0, 0, 0})
+
+	way := protowire.AppendTag(nil, 1, protowire.VarintType)
+	way = protowire.AppendVarint(way, 20)
+	way = packedField(way, 2, []uint64{1, 3})
+	way = packedField(way, 3, []uint64{2, 5})
+	way = packedField(way, 8, zigzag(11, 1))
+	other := protowire.AppendTag(nil, 1, protowire.VarintType)
+	other = protowire.AppendVarint(other, 21)
+	other = packedField(other, 2, []uint64{6})
+	other = packedField(other, 3, []uint64{7})
+	other = packedField(other, 8, zigzag(10, 1))
+
+	group := protowire.AppendTag(nil, 2, protowire.BytesType)
+	group = protowire.AppendBytes(group, dense)
+	group = protowire.AppendTag(group, 3, protowire.BytesType)
+	group = protowire.AppendBytes(group, way)
+	group = protowire.AppendTag(group, 3, protowire.BytesType)
+	group = protowire.AppendBytes(group, other)
+
+	block := protowire.AppendTag(nil, 1, protowire.BytesType)
+	block = protowire.AppendBytes(block, st)
+	block = protowire.AppendTag(block, 2, protowire.BytesType)
+	block = protowire.AppendBytes(block, group)
+
+	header := protowire.AppendTag(nil, 4, protowire.BytesType)
+	header = protowire.AppendString(header, "OsmSchema-V0.6")
+	header = protowire.AppendTag(header, 4, protowire.BytesType)
+	header = protowire.AppendString(header, "DenseNodes")
+
+	var file bytes.Buffer
+	writeBlob(t, &file, "OSMHeader", header, false)
+	writeBlob(t, &file, "OSMData", block, true)
+
+	elements, err := ReadPBF(bytes.NewReader(file.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadPBF: %v", err)
+	}
+	if len(elements) != 2 {
+		t.Fatalf("elements = %+v, want 2", elements)
+	}
+	node := elements[0]
+	if node.Type != "node" || node.ID != 10 || !near(node.Lat, 36.5) || !near(node.Lon, 30.5) || node.Tags["name"] != "Olympos" {
+		t.Errorf("node = %+v", node)
+	}
+	w := elements[1]
+	if w.Type != "way" || w.ID != 20 || !near(w.Lat, 39.95) || !near(w.Lon, 41.15) || w.Tags["name"] != "Ayder" {
+		t.Errorf("way = %+v, want centroid of nodes 11 and 12", w)
+	}
+
+	header = protowire.AppendTag(nil, 4, protowire.BytesType)
+	header = protowire.AppendString(header, "Sort.Type_then_ID")
+	file.Reset()
+	writeBlob(t, &file, "OSMHeader", header, false)
+	if _, err := ReadPBF(bytes.NewReader(file.Bytes())); err == nil {
+		t.Error("unknown required feature accepted")
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+// Mutlak değerleri delta + zigzag kodlar
+func zigzag(first int64, rest ...int64) []uint64 {
+	out := []uint64{protowire.EncodeZigZag(first)}
+	for _, v := range rest {
+		out = append(out, protowire.EncodeZigZag(v))
+	}
+	return out
+}
+
+func packedField(b []byte, num protowire.Number, values []uint64) []byte {
+	var packed []byte
+	for _, v := range values {
+		packed = protowire.AppendVarint(packed, v)
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, packed)
+}
+
+func writeBlob(t *testing.T, w *bytes.Buffer, blobType string, data []byte, compress bool) {
+	t.Helper()
+	var blob []byte
+	if compress {
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		zw.Write(data)
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		blob = protowire.AppendTag(blob, 2, protowire.VarintType)
+		blob = protowire.AppendVarint(blob, uint64(len(data)))
+		blob = protowire.AppendTag(blob, 3, protowire.BytesType)
+		blob = protowire.AppendBytes(blob, z.Bytes())
+	} else {
+		blob = protowire.AppendTag(blob, 1, protowire.BytesType)
+		blob = protowire.AppendBytes(blob, data)
+	}
+
+	header := protowire.AppendTag(nil, 1, protowire.BytesType)
+	header = protowire.AppendString(header, blobType)
+	header = protowire.AppendTag(header, 3, protowire.VarintType)
+	header = protowire.AppendVarint(header, uint64(len(blob)))
+
+	binary.Write(w, binary.BigEndian, uint32(len(header)))
+	w.Write(header)
+	w.Write(blob)
+}
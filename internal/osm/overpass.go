@@ -0,0 +1,87 @@
+package osm
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Overpass API JSON çıktısı ("out center;", "out geom;" veya way düğümleriyle "out;>;")
+type overpassResponse struct {
+	Elements []struct {
+		Type   string            `json:"type"`
+		ID     int64             `json:"id"`
+		Lat    float64           `json:"lat"`
+		Lon    float64           `json:"lon"`
+		Tags   map[string]string `json:"tags"`
+		Nodes  []int64           `json:"nodes"`
+		Center *struct {
+			Lat float64 `json:"lat"`
+			Lon float64 `json:"lon"`
+		} `json:"center"`
+		Geometry []struct {
+			Lat float64 `json:"lat"`
+			Lon float64 `json:"lon"`
+		} `json:"geometry"`
+	} `json:"elements"`
+}
+
+// ReadOverpass Overpass JSON extract'ındaki kamp alanlarını döndürür
+func ReadOverpass(r io.Reader) ([]Element, error) {
+	var response overpassResponse
+	if err := json.NewDecoder(r).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	nodes := map[int64][2]float64{}
+	for _, el := range response.Elements {
+		if el.Type == "node" {
+			nodes[el.ID] = [2]float64{el.Lat, el.Lon}
+		}
+	}
+
+	elements := []Element{}
+	for _, el := range response.Elements {
+		if !IsCampsite(el.Tags) {
+			continue
+		}
+		element := Element{Type: el.Type, ID: el.ID, Lat: el.Lat, Lon: el.Lon, Tags: el.Tags}
+		switch {
+		case el.Type == "node":
+		case el.Type != "way":
+			continue
+		case el.Center != nil:
+			element.Lat, element.Lon = el.Center.Lat, el.Center.Lon
+		case len(el.Geometry) > 0:
+			points := make([][2]float64, len(el.Geometry))
+			for i, p := range el.Geometry {
+				points[i] = [2]float64{p.Lat, p.Lon}
+			}
+			element.Lat, element.Lon = centroid(points)
+		default:
+			points := [][2]float64{}
+			for _, id := range el.Nodes {
+				if p, ok := nodes[id]; ok {
+					points = append(points, p)
+				}
+			}
+			element.Lat, element.Lon = centroid(points)
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+// Kapalı way'lerde tekrar eden son düğüm ortalamayı kaydırmasın diye atılır
+func centroid(points [][2]float64) (lat, lon float64) {
+	if len(points) > 1 && points[0] == points[len(points)-1] {
+		points = points[:len(points)-1]
+	}
+	if len(points) == 0 {
+		return 0, 0
+	}
+	for _, p := range points {
+		lat += p[0]
+		lon += p[1]
+	}
+	return lat / float64(len(points)), lon / float64(len(points))
+}
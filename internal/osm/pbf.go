@@ -0,0 +1,440 @@
+package osm
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// OSM PBF formatının (https://wiki.openstreetmap.org/wiki/PBF_Format) kamp alanları
+// için gereken kısmı: OSMHeader/OSMData blob'ları, string tablosu, Node, DenseNodes
+// ve Way. İlişkiler (relation) atlanır. Yalnızca raw ve zlib sıkıştırma desteklenir.
+const (
+	MAX_BLOB_HEADER_SIZE = 64 << 10
+	MAX_BLOB_SIZE        = 32 << 20
+)
+
+var ErrUnsupportedPBF = errors.New("unsupported PBF feature")
+
+// Okuyucunun anladığı OSMHeader required_features değerleri
+var supportedFeatures = map[string]bool{
+	"OsmSchema-V0.6":        true,
+	"DenseNodes":            true,
+	"HistoricalInformation": true,
+}
+
+// ReadPBF PBF extract'ındaki kamp alanlarını döndürür. Way konumları için
+// dosya iki kez okunur: önce kamp alanı way'leri, sonra yalnızca onların düğümleri.
+func ReadPBF(r io.ReadSeeker) ([]Element, error) {
+	elements := []Element{}
+	ways := []Element{}
+	refs := map[int64][]int64{}
+	err := scanPBF(r, pbfVisitor{
+		node: func(id int64, lat, lon float64, tags map[string]string) {
+			if tags != nil {
+				elements = append(elements, Element{Type: "node", ID: id, Lat: lat, Lon: lon, Tags: tags})
+			}
+		},
+		way: func(id int64, tags map[string]string, nodes []int64) {
+			ways = append(ways, Element{Type: "way", ID: id, Tags: tags})
+			refs[id] = nodes
+		},
+	})
+	if err != nil || len(ways) == 0 {
+		return elements, err
+	}
+
+	wanted := map[int64][2]float64{}
+	for _, nodes := range refs {
+		for _, id := range nodes {
+			wanted[id] = [2]float64{}
+		}
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	err = scanPBF(r, pbfVisitor{
+		node: func(id int64, lat, lon float64, tags map[string]string) {
+			if _, ok := wanted[id]; ok {
+				wanted[id] = [2]float64{lat, lon}
+			}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, way := range ways {
+		points := [][2]float64{}
+		for _, id := range refs[way.ID] {
+			if p := wanted[id]; p != [2]float64{} {
+				points = append(points, p)
+			}
+		}
+		way.Lat, way.Lon = centroid(points)
+		elements = append(elements, way)
+	}
+	return elements, nil
+}
+
+// pbfVisitor node her düğüm için çağrılır (tags yalnızca kamp alanlarında dolu),
+// way yalnızca kamp alanı way'leri için
+type pbfVisitor struct {
+	node func(id int64, lat, lon float64, tags map[string]string)
+	way  func(id int64, tags map[string]string, nodes []int64)
+}
+
+func scanPBF(r io.Reader, v pbfVisitor) error {
+	for {
+		blobType, data, err := readBlob(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch blobType {
+		case "OSMHeader":
+			if err := checkHeader(data); err != nil {
+				return err
+			}
+		case "OSMData":
+			if err := readPrimitiveBlock(data, v); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readBlob sıradaki BlobHeader + Blob çiftini okur ve açılmış içeriği döndürür
+func readBlob(r io.Reader) (string, []byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return "", nil, err
+	}
+	headerSize := binary.BigEndian.Uint32(size[:])
+	if headerSize > MAX_BLOB_HEADER_SIZE {
+		return "", nil, fmt.Errorf("blob header too large: %d", headerSize)
+	}
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return "", nil, unexpectedEOF(err)
+	}
+
+	var blobType string
+	var dataSize uint64
+	err := fields(header, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		switch num {
+		case 1:
+			blobType = string(bytesValue(value))
+		case 3:
+			dataSize, _ = protowire.ConsumeVarint(value)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if dataSize > MAX_BLOB_SIZE {
+		return "", nil, fmt.Errorf("blob too large: %d", dataSize)
+	}
+	blob := make([]byte, dataSize)
+	if _, err := io.ReadFull(r, blob); err != nil {
+		return "", nil, unexpectedEOF(err)
+	}
+
+	var data []byte
+	err = fields(blob, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		switch num {
+		case 1:
+			data = bytesValue(value)
+		case 3:
+			zr, err := zlib.NewReader(bytes.NewReader(bytesValue(value)))
+			if err != nil {
+				return err
+			}
+			defer zr.Close()
+			data, err = io.ReadAll(io.LimitReader(zr, MAX_BLOB_SIZE))
+			return err
+		case 4, 5, 6, 7:
+			return fmt.Errorf("%w: blob compression %d", ErrUnsupportedPBF, num)
+		}
+		return nil
+	})
+	return blobType, data, err
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func checkHeader(data []byte) error {
+	return fields(data, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if num == 4 {
+			if feature := string(bytesValue(value)); !supportedFeatures[feature] {
+				return fmt.Errorf("%w: %s", ErrUnsupportedPBF, feature)
+			}
+		}
+		return nil
+	})
+}
+
+// primitiveBlock string tablosu ve koordinat ölçeği
+type primitiveBlock struct {
+	strings     []string
+	granularity int64
+	latOffset   int64
+	lonOffset   int64
+}
+
+func (b *primitiveBlock) lat(v int64) float64 {
+	return 1e-9 * float64(b.latOffset+b.granularity*v)
+}
+
+func (b *primitiveBlock) lon(v int64) float64 {
+	return 1e-9 * float64(b.lonOffset+b.granularity*v)
+}
+
+// Etiketler yalnızca kamp alanıysa map'e çevrilir, diğerleri için nil
+func (b *primitiveBlock) campsiteTags(keys, vals []uint64) map[string]string {
+	found := false
+	for i := 0; i < len(keys) && i < len(vals); i++ {
+		if b.str(keys[i]) == "tourism" {
+			found = IsCampsite(map[string]string{"tourism": b.str(vals[i])})
+		}
+	}
+	if !found {
+		return nil
+	}
+	tags := make(map[string]string, len(keys))
+	for i := 0; i < len(keys) && i < len(vals); i++ {
+		tags[b.str(keys[i])] = b.str(vals[i])
+	}
+	return tags
+}
+
+func (b *primitiveBlock) str(i uint64) string {
+	if i < uint64(len(b.strings)) {
+		return b.strings[i]
+	}
+	return ""
+}
+
+func readPrimitiveBlock(data []byte, v pbfVisitor) error {
+	block := &primitiveBlock{granularity: 100}
+	groups := [][]byte{}
+	err := fields(data, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		switch num {
+		case 1:
+			return fields(bytesValue(value), func(num protowire.Number, typ protowire.Type, value []byte) error {
+				if num == 1 {
+					block.strings = append(block.strings, string(bytesValue(value)))
+				}
+				return nil
+			})
+		case 2:
+			groups = append(groups, bytesValue(value))
+		case 17:
+			block.granularity = int64(varintValue(value))
+		case 19:
+			block.latOffset = int64(varintValue(value))
+		case 20:
+			block.lonOffset = int64(varintValue(value))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		err := fields(group, func(num protowire.Number, typ protowire.Type, value []byte) error {
+			switch num {
+			case 1:
+				return block.readNode(bytesValue(value), v)
+			case 2:
+				return block.readDenseNodes(bytesValue(value), v)
+			case 3:
+				return block.readWay(bytesValue(value), v)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *primitiveBlock) readNode(data []byte, v pbfVisitor) error {
+	var id, lat, lon int64
+	var keys, vals []uint64
+	err := fields(data, func(num protowire.Number, typ protowire.Type, value []byte) (err error) {
+		switch num {
+		case 1:
+			id = protowire.DecodeZigZag(varintValue(value))
+		case 2:
+			keys, err = appendVarints(keys, typ, value)
+		case 3:
+			vals, err = appendVarints(vals, typ, value)
+		case 8:
+			lat = protowire.DecodeZigZag(varintValue(value))
+		case 9:
+			lon = protowire.DecodeZigZag(varintValue(value))
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if v.node != nil {
+		v.node(id, b.lat(lat), b.lon(lon), b.campsiteTags(keys, vals))
+	}
+	return nil
+}
+
+// DenseNodes: id, lat ve lon delta kodlu; keys_vals her düğüm için 0 ile biten anahtar/değer çiftleri
+func (b *primitiveBlock) readDenseNodes(data []byte, v pbfVisitor) error {
+	var ids, lats, lons, keysVals []uint64
+	err := fields(data, func(num protowire.Number, typ protowire.Type, value []byte) (err error) {
+		switch num {
+		case 1:
+			ids, err = appendVarints(ids, typ, value)
+		case 8:
+			lats, err = appendVarints(lats, typ, value)
+		case 9:
+			lons, err = appendVarints(lons, typ, value)
+		case 10:
+			keysVals, err = appendVarints(keysVals, typ, value)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(lats) != len(ids) || len(lons) != len(ids) {
+		return fmt.Errorf("dense nodes: %d ids, %d lats, %d lons", len(ids), len(lats), len(lons))
+	}
+	if v.node == nil {
+		return nil
+	}
+
+	var id, lat, lon int64
+	kv := 0
+	for i := range ids {
+		id += protowire.DecodeZigZag(ids[i])
+		lat += protowire.DecodeZigZag(lats[i])
+		lon += protowire.DecodeZigZag(lons[i])
+
+		var keys, vals []uint64
+		for kv < len(keysVals) && keysVals[kv] != 0 {
+			if kv+1 < len(keysVals) {
+				keys = append(keys, keysVals[kv])
+				vals = append(vals, keysVals[kv+1])
+			}
+			kv += 2
+		}
+		kv++
+		v.node(id, b.lat(lat), b.lon(lon), b.campsiteTags(keys, vals))
+	}
+	return nil
+}
+
+func (b *primitiveBlock) readWay(data []byte, v pbfVisitor) error {
+	var id int64
+	var keys, vals []uint64
+	var refs []byte
+	var refsType protowire.Type
+	err := fields(data, func(num protowire.Number, typ protowire.Type, value []byte) (err error) {
+		switch num {
+		case 1:
+			id = int64(varintValue(value))
+		case 2:
+			keys, err = appendVarints(keys, typ, value)
+		case 3:
+			vals, err = appendVarints(vals, typ, value)
+		case 8:
+			refs, refsType = value, typ
+		}
+		return err
+	})
+	if err != nil || v.way == nil {
+		return err
+	}
+	tags := b.campsiteTags(keys, vals)
+	if tags == nil {
+		return nil
+	}
+
+	var deltas []uint64
+	if refs != nil {
+		if deltas, err = appendVarints(nil, refsType, refs); err != nil {
+			return err
+		}
+	}
+	nodes := make([]int64, len(deltas))
+	var ref int64
+	for i, delta := range deltas {
+		ref += protowire.DecodeZigZag(delta)
+		nodes[i] = ref
+	}
+	v.way(id, tags, nodes)
+	return nil
+}
+
+// fields mesajdaki her alanı numarası, tipi ve ham değeriyle fn'e verir
+func fields(b []byte, fn func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		m := protowire.ConsumeFieldValue(num, typ, b)
+		if m < 0 {
+			return protowire.ParseError(m)
+		}
+		if err := fn(num, typ, b[:m]); err != nil {
+			return err
+		}
+		b = b[m:]
+	}
+	return nil
+}
+
+func bytesValue(value []byte) []byte {
+	v, _ := protowire.ConsumeBytes(value)
+	return v
+}
+
+func varintValue(value []byte) uint64 {
+	v, _ := protowire.ConsumeVarint(value)
+	return v
+}
+
+// Packed veya tek tek yazılmış varint alanlarını dst'ye ekler
+func appendVarints(dst []uint64, typ protowire.Type, value []byte) ([]uint64, error) {
+	switch typ {
+	case protowire.VarintType:
+		return append(dst, varintValue(value)), nil
+	case protowire.BytesType:
+		packed := bytesValue(value)
+		for len(packed) > 0 {
+			v, n := protowire.ConsumeVarint(packed)
+			if n < 0 {
+				return dst, protowire.ParseError(n)
+			}
+			dst = append(dst, v)
+			packed = packed[n:]
+		}
+	}
+	return dst, nil
+}
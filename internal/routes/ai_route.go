@@ -5,14 +5,22 @@ import (
 	"ai-routes-service/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
-
-	
 )
 
-func AIRoute(router fiber.Router, aiHandler handler.AIHandlerInterface){
+func AIRoute(router fiber.Router, aiHandler handler.AIHandlerInterface) {
 
 	api := router.Group("/api/v1")
 
-	api.Post("/ai",middleware.AIMiddleware,aiHandler.GenerateTripPlanHandler)
+	api.Post("/ai", middleware.AIMiddleware, aiHandler.GenerateTripPlanHandler)
+	api.Post("/ai/stream", middleware.AIMiddleware, aiHandler.StreamTripPlanHandler)
+
+	api.Post("/plans", middleware.AIMiddleware, aiHandler.SubmitPlanJobHandler)
+	// Uzantılı rota önce kaydedilmeli: /plans/{id}.gpx|.kml|.geojson
+	api.Get("/plans/:id.:format", aiHandler.ExportPlanHandler)
+	api.Get("/plans/:id", aiHandler.GetPlanJobHandler)
+	api.Post("/plans/:id/revise", aiHandler.RevisePlanHandler)
+	api.Post("/plans/:id/days/:day/regenerate", aiHandler.RegenerateDayHandler)
+
+	api.Get("/users/:id/plans", aiHandler.ListUserPlansHandler)
 
-}
\ No newline at end of file
+}
@@ -1,10 +1,13 @@
 package services
 
 import (
+	"ai-routes-service/internal/geo"
 	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/storage"
 	"ai-routes-service/internal/utils"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -14,10 +17,28 @@ import (
 )
 
 type AIService struct {
-	Client          *genai.Client
-	Model           string
-	GoogleSearchKey string
-	GoogleSearchCX  string
+	Provider Provider
+	Search   utils.SearchProvider
+	// Doğrulama ihlalleri için modelden istenecek en fazla düzeltme turu
+	MaxRepairRounds int
+	// StrictMode açıkken sentetik fallback plan yerine ErrPlanUnavailable döner
+	StrictMode bool
+	// Arama istekleri arasındaki bekleme süresi (rate limiting)
+	SearchInterval time.Duration
+	// Store set ise her istek ve sonucu kaydedilir
+	Store storage.PlanStore
+	// Geo set ise koordinatlar bölge ve adres tutarlılığı için kontrol edilir
+	Geo *GeoValidator
+	// Geocoding set ise kamp alanı koordinatları geocoder sonucuyla düzeltilir
+	Geocoding *GeoCorrector
+	// Distances set ise günlük mesafeler hesaplanır ve sınırlar kontrol edilir
+	Distances *DistanceChecker
+	// Optimizer set ise konak sırası geri dönüşleri azaltacak şekilde düzenlenir
+	Optimizer *RouteOptimizer
+	// Campsites set ise güzergah boyunca katalogdaki kamp alanları prompt'a eklenir
+	Campsites *CampsiteRetriever
+	// Links set ise konakların site_url'leri kontrol edilir
+	Links *LinkChecker
 }
 
 // Konservatif sabitler
@@ -26,52 +47,156 @@ const (
 	MAX_SEARCH_RESULTS = 2
 	MAX_ITERATIONS     = 3
 	REQUEST_TIMEOUT    = 3 * time.Minute
+	MAX_REPAIR_ROUNDS  = 2
+	SEARCH_INTERVAL    = 1 * time.Second
 )
 
-func NewAIService(apiKey string, model string, googleSearchKey string, googleSearchCX string) (*AIService, error) {
-	ctx := context.Background()
-	client, err := genai.NewClient(ctx, &genai.ClientConfig{APIKey: apiKey})
+// ErrPlanUnavailable StrictMode açıkken modelden gerçek bir plan alınamadığında döner
+var ErrPlanUnavailable = errors.New("trip plan unavailable")
+
+// Fallback plan üretilme sebepleri (PlanResult.DegradedReason)
+const (
+	DegradedGenerationFailed = "generation_failed"
+	DegradedEmptyResponse    = "empty_response"
+	DegradedInvalidResponse  = "invalid_response"
+	DegradedContextTooLong   = "context_too_long"
+	DegradedMaxIterations    = "max_iterations"
+	DegradedMissingNights    = "missing_nights"
+)
+
+func NewAIService(provider Provider, search utils.SearchProvider) (*AIService, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("llm provider is required")
+	}
+	if search == nil {
+		return nil, fmt.Errorf("search provider is required")
+	}
+	return &AIService{
+		Provider:        provider,
+		Search:          search,
+		MaxRepairRounds: MAX_REPAIR_ROUNDS,
+		SearchInterval:  SEARCH_INTERVAL,
+		Geo:             NewGeoValidator(geo.Turkey),
+		Distances:       NewDistanceChecker(),
+		Optimizer:       NewRouteOptimizer(),
+	}, nil
+}
+
+// GenerateTripPlan çağıranın context'ine REQUEST_TIMEOUT ekleyerek plan üretir.
+// Context iptal edilirse arama ve model çağrıları durur, fallback üretilmez.
+func (s *AIService) GenerateTripPlan(ctx context.Context, prompt models.PromptBody) (*models.PlanResult, error) {
+	prompt, err := NormalizePrompt(prompt)
 	if err != nil {
 		return nil, err
 	}
-	return &AIService{Client: client, Model: model, GoogleSearchKey: googleSearchKey, GoogleSearchCX: googleSearchCX}, nil
+	started := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, REQUEST_TIMEOUT)
+	defer cancel()
+	result, err := s.twoStageGeneration(ctx, prompt)
+	s.savePlan(ctx, prompt, result, err, started)
+	if err == nil {
+		reportProgress(ctx, models.ProgressEvent{Stage: models.ProgressPlan, Result: result})
+	}
+	return result, err
+}
+
+// İsteği ve sonucunu Store'a kaydeder, başarılıysa result.ID'yi doldurur.
+// Kayıt hatası isteği başarısız yapmaz.
+func (s *AIService) savePlan(ctx context.Context, prompt models.PromptBody, result *models.PlanResult, genErr error, started time.Time) {
+	if s.Store == nil {
+		return
+	}
+	finished := time.Now()
+	record := &models.PlanRecord{
+		ID:         planIDFromContext(ctx),
+		UserID:     prompt.UserID,
+		Version:    1,
+		Prompt:     prompt,
+		Result:     result,
+		Model:      s.Provider.ModelName(),
+		StartedAt:  started,
+		FinishedAt: finished,
+		DurationMs: finished.Sub(started).Milliseconds(),
+	}
+	if genErr != nil {
+		record.Error = genErr.Error()
+	}
+	s.storeRecord(ctx, record)
 }
 
-func (s *AIService) GenerateTripPlan(prompt models.PromptBody) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+// Kaydı Store'a yazar, başarılıysa result.ID'yi doldurur
+func (s *AIService) storeRecord(ctx context.Context, record *models.PlanRecord) error {
+	// İstek iptal edilmiş olsa bile kayıt tamamlanmalı
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
 	defer cancel()
-	return s.twoStageGeneration(ctx, prompt)
+	if err := s.Store.SavePlan(ctx, record); err != nil {
+		log.Printf("⚠️ Plan kaydedilemedi: %v", err)
+		return err
+	}
+	if record.Result != nil {
+		record.Result.ID = record.ID
+	}
+	log.Printf("🗄️ Plan kaydedildi: %s (%d ms)", record.ID, record.DurationMs)
+	return nil
 }
 
-func (s *AIService) twoStageGeneration(ctx context.Context, prompt models.PromptBody) (string, error) {
+func (s *AIService) twoStageGeneration(ctx context.Context, prompt models.PromptBody) (*models.PlanResult, error) {
 	log.Printf("🎯 Starting two-stage generation")
-	searchResults, err := s.performManualSearches(prompt)
+	searchResults, err := s.performManualSearches(ctx, prompt)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	if err != nil {
 		log.Printf("⚠️ Search failed, continuing without: %v", err)
 		searchResults = "Arama yapılamadı, genel bilgilerle plan oluşturulacak."
 	} else {
 		searchResults = summarizeSearchResults(searchResults, 20) // 🔍 EKLENDİ: Uzunluğu kısıtla
 	}
+	if catalogue := s.catalogueContext(ctx, s.resolvePosition(ctx, prompt.StartPosition), s.resolvePosition(ctx, prompt.EndPosition), prompt.Description); catalogue != "" {
+		searchResults = catalogue + "\n" + searchResults
+	}
 	return s.generatePlanWithSearchResults(ctx, prompt, searchResults)
 }
 
+// preparePlan model planını doğrulamadan önce sunucuda hesaplanan alanlarla
+// tamamlar: tarihler, geocoder düzeltmeleri, rota uçlarının koordinatları, konak sırası, mesafeler ve link kontrolleri.
+// memo düzeltme turları arasında paylaşılır, nil olabilir.
+func (s *AIService) preparePlan(ctx context.Context, prompt models.PromptBody, plan *models.TripPlan, memo GeocodeMemo) ([]models.CoordinateCorrection, *models.RouteOptimization) {
+	normalizeDays(prompt, plan)
+	var corrections []models.CoordinateCorrection
+	if s.Geocoding != nil {
+		corrections = s.Geocoding.Correct(ctx, plan, memo)
+		s.Geocoding.locateEndpoints(ctx, prompt, plan, memo)
+	}
+	optimization := s.optimizeOrder(ctx, prompt, plan)
+	s.measureDistances(ctx, prompt, plan)
+	s.checkLinks(ctx, plan)
+	return corrections, optimization
+}
+
 // Manual search yapma
-func (s *AIService) performManualSearches(prompt models.PromptBody) (string, error) {
+func (s *AIService) performManualSearches(ctx context.Context, prompt models.PromptBody) (string, error) {
 	log.Printf("🔍 Performing manual searches...")
 	queries := []string{
 		fmt.Sprintf("%s %s kamp alanları", prompt.StartPosition, prompt.EndPosition),
 		fmt.Sprintf("%s kamp yerleri koordinat", prompt.StartPosition),
 		fmt.Sprintf("%s camping sites", prompt.EndPosition),
 	}
+	return s.runSearches(ctx, queries)
+}
 
+// Sorguları sırayla çalıştırıp sonuçları tek metinde birleştirir
+func (s *AIService) runSearches(ctx context.Context, queries []string) (string, error) {
 	allResults := ""
 	for i, query := range queries {
 		log.Printf("🔍 Search %d: %s", i+1, query)
-		result := s.performSingleSearch(query)
+		result := s.performSingleSearch(ctx, query)
 		if result != "" {
 			allResults += fmt.Sprintf("\n=== ARAMA %d: %s ===\n%s\n", i+1, query, result)
 		}
-		time.Sleep(1 * time.Second)
+		if err := sleepContext(ctx, s.SearchInterval); err != nil {
+			return "", err
+		}
 		if len(allResults) > 8000 {
 			break
 		}
@@ -99,24 +224,24 @@ func summarizeSearchResults(fullText string, maxLines int) string {
 	return strings.Join(importantLines, "\n")
 }
 
-
-
 // Tek search yapma
-func (s *AIService) performSingleSearch(query string) string {
-	searchResults, err := utils.PerformSearch(query, s.GoogleSearchKey, s.GoogleSearchCX)
+func (s *AIService) performSingleSearch(ctx context.Context, query string) string {
+	reportProgress(ctx, models.ProgressEvent{Stage: models.ProgressSearchQuery, Query: query})
+	items, err := s.Search.Search(ctx, query)
+	reportProgress(ctx, models.ProgressEvent{Stage: models.ProgressSearchResults, Query: query, Count: len(items)})
 
-	if err != nil || searchResults == nil || len(searchResults.Items) == 0 {
+	if err != nil || len(items) == 0 {
 		return fmt.Sprintf("'%s' için sonuç bulunamadı", query)
 	}
 
 	resultStr := ""
 	maxResults := MAX_SEARCH_RESULTS
-	if len(searchResults.Items) < maxResults {
-		maxResults = len(searchResults.Items)
+	if len(items) < maxResults {
+		maxResults = len(items)
 	}
 
 	for i := 0; i < maxResults; i++ {
-		item := searchResults.Items[i]
+		item := items[i]
 
 		title := item.Title
 		if len(title) > 80 {
@@ -134,11 +259,8 @@ func (s *AIService) performSingleSearch(query string) string {
 	return resultStr
 }
 
-// Search sonuçlarıyla plan oluşturma
-func (s *AIService) generatePlanWithSearchResults(ctx context.Context, prompt models.PromptBody, searchResults string) (string, error) {
-	log.Printf("🎯 Generating plan with search results...")
-
-	systemPrompt := `# Kamp Rotası Planlama AI - Tam Dinamik Sistem
+// Plan üretimi ve revizyonunda kullanılan sistem prompt'u
+const planSystemPrompt = `# Kamp Rotası Planlama AI - Tam Dinamik Sistem
 
 Sen akıllı bir kamp rotası planlama uzmanısın. Kullanıcının verdiği bilgilere göre **tamamen araştırma bazlı** kamp rotası oluşturacaksın.
 
@@ -179,21 +301,23 @@ json
     "name": "kullanıcının_girdiği_isim",
     "description": "kullanıcının_açıklaması",
     "start_position": "başlangıç",
-    "end_position": "bitiş", 
+    "end_position": "bitiş",
     "start_date": "2024-08-01",
     "end_date": "2024-08-07",
     "total_days": 7,
+    "route_summary": "GÜZERGAHIN_KISA_ÖZETİ"
   },
   "daily_plan": [
     {
       "day": 1,
-      "date": "2024-08-01", 
+      "date": "2024-08-01",
       "location": {
         "name": "ARAŞTIRDIĞIN_GERÇEK_KAMP_ALANI",
         "address": "TAM_ADRES_BİLGİSİ_MAH_CAD_NO_İLÇE_İL",
         "site_url": "https://gerçek-web-sitesi.com",
         "latitude": 37.123456,
         "longitude": 27.654321,
+        "notes": "SEZON_REZERVASYON_ULAŞIM_NOTLARI"
       }
     }
   ]
@@ -222,12 +346,33 @@ json
 
 BAŞLA VE ARAŞTIR!`
 
+// Basit konfigürasyon - function call YOK, çıktı TripPlan şemasına bağlı
+func planGenerationConfig() *genai.GenerateContentConfig {
+	return &genai.GenerateContentConfig{
+		SystemInstruction: genai.Text(planSystemPrompt)[0],
+		MaxOutputTokens:   4096,
+		ResponseMIMEType:  "application/json",
+		ResponseSchema:    tripPlanSchema,
+		SafetySettings: []*genai.SafetySetting{
+			{Category: genai.HarmCategoryDangerousContent, Threshold: genai.HarmBlockThresholdBlockNone},
+			{Category: genai.HarmCategoryHarassment, Threshold: genai.HarmBlockThresholdBlockNone},
+			{Category: genai.HarmCategoryHateSpeech, Threshold: genai.HarmBlockThresholdBlockNone},
+			{Category: genai.HarmCategorySexuallyExplicit, Threshold: genai.HarmBlockThresholdBlockNone},
+		},
+	}
+}
+
+// Search sonuçlarıyla plan oluşturma
+func (s *AIService) generatePlanWithSearchResults(ctx context.Context, prompt models.PromptBody, searchResults string) (*models.PlanResult, error) {
+	log.Printf("🎯 Generating plan with search results...")
+
 	userPrompt := fmt.Sprintf(`KAMP ROTASI BİLGİLERİ:
 ID: %s
 İsim: %s
 Açıklama: %s
 Başlangıç: %s → Bitiş: %s
 Tarih: %s - %s
+%s
 
 ARAMA SONUÇLARI:
 %s
@@ -236,51 +381,130 @@ Bu bilgileri kullanarak JSON formatında kamp rotası planı oluştur.`,
 		prompt.UserID, prompt.Name, prompt.Description,
 		prompt.StartPosition, prompt.EndPosition,
 		prompt.StartDate, prompt.EndDate,
+		nightsInstruction(prompt)+preferencesSection(prompt),
 		searchResults)
 
-	// Basit konfigürasyon - function call YOK
-	config := &genai.GenerateContentConfig{
-		SystemInstruction: genai.Text(systemPrompt)[0],
-		MaxOutputTokens:   4096,
-		SafetySettings: []*genai.SafetySetting{
-			{Category: genai.HarmCategoryDangerousContent, Threshold: genai.HarmBlockThresholdBlockNone},
-			{Category: genai.HarmCategoryHarassment, Threshold: genai.HarmBlockThresholdBlockNone},
-			{Category: genai.HarmCategoryHateSpeech, Threshold: genai.HarmBlockThresholdBlockNone},
-			{Category: genai.HarmCategorySexuallyExplicit, Threshold: genai.HarmBlockThresholdBlockNone},
-		},
-	}
+	config := planGenerationConfig()
 
 	contents := []*genai.Content{
 		genai.NewContentFromText(userPrompt, genai.RoleUser),
 	}
 
-	// Tek seferde response al
-	resp, err := s.Client.Models.GenerateContent(ctx, s.Model, contents, config)
+	// Tek seferde response al (ilerleme dinleniyorsa streaming ile)
+	reportProgress(ctx, models.ProgressEvent{Stage: models.ProgressGenerationStarted, Message: s.Provider.ModelName()})
+	resp, err := s.generatePlanContent(ctx, contents, config)
+	if ctx.Err() != nil {
+		log.Printf("🛑 Generation cancelled: %v", ctx.Err())
+		return nil, ctx.Err()
+	}
 	if err != nil {
 		log.Printf("❌ Generation failed: %v", err)
 		// Fallback response döndür
-		return s.generateFallbackWithSearch(prompt, searchResults), nil
+		return s.fallbackResult(prompt, searchResults, DegradedGenerationFailed)
 	}
 
 	if resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
 		log.Printf("⚠️ Empty response received")
-		return s.generateFallbackWithSearch(prompt, searchResults), nil
+		return s.fallbackResult(prompt, searchResults, DegradedEmptyResponse)
 	}
 
 	response := resp.Text()
 	log.Printf("✅ Plan generation successful: %d chars", len(response))
 
-	cleaned := s.cleanJSONResponse(response)
-	if cleaned == "" {
-		log.Printf("⚠️ JSON cleaning failed")
-		return s.generateFallbackWithSearch(prompt, searchResults), nil
+	plan, err := s.parseTripPlan(response)
+	if err != nil {
+		log.Printf("⚠️ Plan parse failed: %v", err)
+		return s.fallbackResult(prompt, searchResults, DegradedInvalidResponse)
+	}
+
+	result, err := s.repairPlan(ctx, prompt, plan, contents, resp.Candidates[0].Content, config)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return s.fallbackResult(prompt, searchResults, DegradedMissingNights)
+	}
+	return result, nil
+}
+
+// Doğrulama ihlallerini modele geri besleyip en fazla MaxRepairRounds tur düzeltme ister.
+// Kalan ihlaller sonuçla birlikte döner. Eksik geceler tamamlanamazsa hata döner.
+func (s *AIService) repairPlan(ctx context.Context, prompt models.PromptBody, plan *models.TripPlan, contents []*genai.Content, modelContent *genai.Content, config *genai.GenerateContentConfig) (*models.PlanResult, error) {
+	memo := GeocodeMemo{}
+	corrections, optimization := s.preparePlan(ctx, prompt, plan, memo)
+	if optimization != nil && optimization.Applied {
+		modelContent = planContent(plan, modelContent)
+	}
+	violations := s.validatePlan(plan, prompt)
+	reportProgress(ctx, models.ProgressEvent{Stage: models.ProgressValidation, Violations: violations})
+
+	for round := 1; round <= s.MaxRepairRounds && len(violations) > 0; round++ {
+		log.Printf("🔧 Repair round %d/%d: %d violations", round, s.MaxRepairRounds, len(violations))
+		reportProgress(ctx, models.ProgressEvent{Stage: models.ProgressRepair, Round: round, Violations: violations})
+
+		contents = append(contents, modelContent, genai.NewContentFromText(repairPrompt(violations), genai.RoleUser))
+		resp, err := s.Provider.Generate(ctx, contents, config)
+		if err != nil || resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+			log.Printf("⚠️ Repair round failed: %v", err)
+			break
+		}
+		modelContent = resp.Candidates[0].Content
+
+		repaired, err := s.parseTripPlan(resp.Text())
+		if err != nil {
+			log.Printf("⚠️ Repaired plan parse failed: %v", err)
+			break
+		}
+
+		// Düzeltme daha kötü sonuç verdiyse önceki planı koru
+		repairedCorrections, repairedOptimization := s.preparePlan(ctx, prompt, repaired, memo)
+		if repairedOptimization != nil && repairedOptimization.Applied {
+			modelContent = planContent(repaired, modelContent)
+		}
+		repairedViolations := s.validatePlan(repaired, prompt)
+		reportProgress(ctx, models.ProgressEvent{Stage: models.ProgressValidation, Round: round, Violations: repairedViolations})
+		if len(repairedViolations) <= len(violations) {
+			plan, violations, corrections, optimization = repaired, repairedViolations, repairedCorrections, repairedOptimization
+		}
 	}
 
-	return cleaned, nil
+	days := len(plan.DailyPlan)
+	filled, err := s.completeNights(ctx, prompt, plan)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.DailyPlan) != days {
+		corrections = append(corrections, filled...)
+		violations = s.validatePlan(plan, prompt)
+	}
+
+	if len(violations) > 0 {
+		log.Printf("⚠️ Plan has %d unresolved violations", len(violations))
+	} else {
+		log.Printf("✅ Plan validation passed")
+	}
+	return &models.PlanResult{Plan: plan, Status: models.PlanStatusOK, Violations: violations, Corrections: corrections, Optimization: optimization}, nil
+}
+
+// Model plan üretemediğinde sentetik planı degraded olarak işaretler,
+// StrictMode açıksa plan yerine hata döndürür
+func (s *AIService) fallbackResult(prompt models.PromptBody, searchResults string, reason string) (*models.PlanResult, error) {
+	if s.StrictMode {
+		log.Printf("❌ Plan unavailable (strict mode): %s", reason)
+		return nil, fmt.Errorf("%w: %s", ErrPlanUnavailable, reason)
+	}
+
+	plan := s.generateFallbackWithSearch(prompt, searchResults)
+	return &models.PlanResult{
+		Plan:           plan,
+		Status:         models.PlanStatusDegraded,
+		DegradedReason: reason,
+		Violations:     s.validatePlan(plan, prompt),
+	}, nil
 }
 
 // Search sonuçlarıyla fallback
-func (s *AIService) generateFallbackWithSearch(prompt models.PromptBody, searchResults string) string {
+func (s *AIService) generateFallbackWithSearch(prompt models.PromptBody, searchResults string) *models.TripPlan {
 	log.Printf("🔄 Generating fallback with search results")
 
 	// Search sonuçlarından kamp alanı ismi çıkarmaya çalış
@@ -303,41 +527,46 @@ func (s *AIService) generateFallbackWithSearch(prompt models.PromptBody, searchR
 		}
 	}
 
-	return fmt.Sprintf(`{
-  "trip": {
-    "user_id": "%s",
-    "name": "%s", 
-    "description": "%s",
-    "start_position": "%s",
-    "end_position": "%s",
-    "start_date": "%s",
-    "end_date": "%s",
-    "total_days": 1,
-    "route_summary": "Arama sonuçları kullanılarak oluşturulan kamp rotası planı."
-  },
-  "daily_plan": [
-    {
-      "day": 1,
-      "date": "%s",
-      "location": {
-        "name": "%s",
-        "address": "%s bölgesi",
-        "site_url": "",
-        "latitude": 39.9334,
-        "longitude": 32.8597,
-        "notes": "Arama sonuçlarından alınan bilgiler. Detaylı bilgi için araştırma yapılması önerilir."
-      }
-    }
-  ]
-}`, prompt.UserID, prompt.Name, prompt.Description,
-		prompt.StartPosition, prompt.EndPosition,
-		prompt.StartDate, prompt.EndDate,
-		prompt.StartDate, campName, prompt.StartPosition)
+	plan := &models.TripPlan{
+		Trip: models.Trip{
+			UserID:        prompt.UserID,
+			Name:          prompt.Name,
+			Description:   prompt.Description,
+			StartPosition: prompt.StartPosition,
+			EndPosition:   prompt.EndPosition,
+			StartDate:     prompt.StartDate,
+			EndDate:       prompt.EndDate,
+			RouteSummary:  "Arama sonuçları kullanılarak oluşturulan kamp rotası planı.",
+		},
+	}
+	// Her gece için bir kayıt; tarihler normalizeDays ile yazılır
+	nights := 1
+	if _, n, ok := promptNights(prompt); ok && n > 0 {
+		nights = n
+	}
+	for range nights {
+		plan.DailyPlan = append(plan.DailyPlan, models.DailyPlan{
+			Location: models.Location{
+				Name:      campName,
+				Address:   prompt.StartPosition + " bölgesi",
+				Latitude:  39.9334,
+				Longitude: 32.8597,
+				Notes:     "Arama sonuçlarından alınan bilgiler. Detaylı bilgi için araştırma yapılması önerilir.",
+			},
+		})
+	}
+	normalizeDays(prompt, plan)
+	return plan
 }
 
 // Gelişmiş function call versiyonu (alternatif)
-func (s *AIService) GenerateTripPlanWithFunctionCalls(prompt models.PromptBody) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), REQUEST_TIMEOUT)
+func (s *AIService) GenerateTripPlanWithFunctionCalls(ctx context.Context, prompt models.PromptBody) (*models.PlanResult, error) {
+	prompt, err := NormalizePrompt(prompt)
+	if err != nil {
+		return nil, err
+	}
+	started := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, REQUEST_TIMEOUT)
 	defer cancel()
 
 	log.Printf("🤖 Starting function call generation")
@@ -358,10 +587,12 @@ JSON ÇıKTı:
 	userPrompt := fmt.Sprintf(`Kamp rotası planla:
 %s → %s (%s - %s)
 İsim: %s
+%s
 
 Gerçek kamp alanları araştır ve JSON planı oluştur.`,
 		prompt.StartPosition, prompt.EndPosition,
-		prompt.StartDate, prompt.EndDate, prompt.Name)
+		prompt.StartDate, prompt.EndDate, prompt.Name,
+		nightsInstruction(prompt)+preferencesSection(prompt))
 
 	// Google Search tool
 	googleSearchTool := genai.Tool{
@@ -385,20 +616,21 @@ Gerçek kamp alanları araştır ve JSON planı oluştur.`,
 
 	config := &genai.GenerateContentConfig{
 		SystemInstruction: genai.Text(systemPrompt)[0],
-		Tools:             []*genai.Tool{&googleSearchTool},
-
-		MaxOutputTokens: 3072,
+		MaxOutputTokens:   3072,
 	}
 
 	contents := []*genai.Content{
 		genai.NewContentFromText(userPrompt, genai.RoleUser),
 	}
 
-	return s.managedConversation(ctx, contents, config, prompt)
+	result, err := s.managedConversation(ctx, contents, config, []*genai.Tool{&googleSearchTool}, prompt)
+	s.savePlan(ctx, prompt, result, err, started)
+	return result, err
 }
 
 // Basit conversation management
-func (s *AIService) managedConversation(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, prompt models.PromptBody) (string, error) {
+func (s *AIService) managedConversation(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, tools []*genai.Tool, prompt models.PromptBody) (*models.PlanResult, error) {
+	reason := DegradedMaxIterations
 
 	for iteration := 1; iteration <= MAX_ITERATIONS; iteration++ {
 		log.Printf("🤖 Iteration %d/%d", iteration, MAX_ITERATIONS)
@@ -406,17 +638,23 @@ func (s *AIService) managedConversation(ctx context.Context, contents []*genai.C
 		// Context kontrolü
 		if s.getContextLength(contents) > MAX_CONTEXT_LENGTH {
 			log.Printf("⚠️ Context too long, stopping")
+			reason = DegradedContextTooLong
 			break
 		}
 
-		resp, err := s.Client.Models.GenerateContent(ctx, s.Model, contents, config)
+		resp, err := s.Provider.GenerateWithTools(ctx, contents, config, tools)
+		if ctx.Err() != nil {
+			log.Printf("🛑 Conversation cancelled: %v", ctx.Err())
+			return nil, ctx.Err()
+		}
 		if err != nil {
 			log.Printf("❌ API Error: %v", err)
-			return s.generateFallbackWithSearch(prompt, "API hatası nedeniyle arama yapılamadı"), nil
+			return s.fallbackResult(prompt, "API hatası nedeniyle arama yapılamadı", DegradedGenerationFailed)
 		}
 
 		if resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
 			log.Printf("⚠️ Empty response")
+			reason = DegradedEmptyResponse
 			break
 		}
 
@@ -434,7 +672,7 @@ func (s *AIService) managedConversation(ctx context.Context, contents []*genai.C
 				}
 
 				log.Printf("🔍 Search: %s", query)
-				searchResult := s.performSingleSearch(query)
+				searchResult := s.performSingleSearch(ctx, query)
 
 				// Search response ekle
 				contents = append(contents, &genai.Content{
@@ -452,7 +690,9 @@ func (s *AIService) managedConversation(ctx context.Context, contents []*genai.C
 				})
 
 				// Rate limiting
-				time.Sleep(2 * time.Second)
+				if err := sleepContext(ctx, 2*s.SearchInterval); err != nil {
+					return nil, err
+				}
 				break // Sadece ilk search'ü işle
 			}
 		}
@@ -462,16 +702,41 @@ func (s *AIService) managedConversation(ctx context.Context, contents []*genai.C
 			finalResponse := resp.Text()
 			log.Printf("🎯 Final response: %d chars", len(finalResponse))
 
-			cleaned := s.cleanJSONResponse(finalResponse)
-			if cleaned == "" {
-				return finalResponse, nil
+			plan, err := s.parseTripPlan(finalResponse)
+			if err != nil {
+				log.Printf("⚠️ Plan parse failed: %v", err)
+				return s.fallbackResult(prompt, "Model yanıtı JSON formatında değildi", DegradedInvalidResponse)
 			}
 
-			return cleaned, nil
+			corrections, optimization := s.preparePlan(ctx, prompt, plan, nil)
+			filled, err := s.completeNights(ctx, prompt, plan)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				return s.fallbackResult(prompt, "Eksik geceler tamamlanamadı", DegradedMissingNights)
+			}
+			corrections = append(corrections, filled...)
+			return &models.PlanResult{Plan: plan, Status: models.PlanStatusOK, Violations: s.validatePlan(plan, prompt), Corrections: corrections, Optimization: optimization}, nil
 		}
 	}
 
-	return s.generateFallbackWithSearch(prompt, "Maksimum iterasyon sayısına ulaşıldı"), nil
+	return s.fallbackResult(prompt, "Maksimum iterasyon sayısına ulaşıldı", reason)
+}
+
+// Context iptal edilene kadar veya d süresi kadar bekler
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // Context uzunluğu hesaplama
@@ -544,9 +809,23 @@ func (s *AIService) cleanJSONResponse(response string) string {
 	return result
 }
 
+// Model yanıtını temizleyip TripPlan'a çevirir
+func (s *AIService) parseTripPlan(response string) (*models.TripPlan, error) {
+	cleaned := s.cleanJSONResponse(response)
+	if cleaned == "" {
+		return nil, fmt.Errorf("no valid JSON object in response")
+	}
+
+	var plan models.TripPlan
+	if err := json.Unmarshal([]byte(cleaned), &plan); err != nil {
+		return nil, fmt.Errorf("plan JSON does not match schema: %w", err)
+	}
+	return &plan, nil
+}
+
 // Test fonksiyonu
-func (s *AIService) TestConnection() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func (s *AIService) TestConnection(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	log.Printf("🔍 Testing API connection...")
@@ -559,13 +838,13 @@ func (s *AIService) TestConnection() error {
 		genai.NewContentFromText("Test mesajı. Sadece 'OK' yanıtını ver.", genai.RoleUser),
 	}
 
-	resp, err := s.Client.Models.GenerateContent(ctx, s.Model, contents, config)
+	resp, err := s.Provider.Generate(ctx, contents, config)
 	if err != nil {
 		return fmt.Errorf("connection test failed: %w", err)
 	}
 
 	if resp != nil && len(resp.Candidates) > 0 {
-		log.Printf("✅ API test successful: %s", resp.Text())
+		log.Printf("✅ API test successful (%s): %s", s.Provider.ModelName(), resp.Text())
 		return nil
 	}
 
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/storage"
+	"ai-routes-service/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Prompt veya config değiştiğinde: go test ./internal/services -update
+var update = flag.Bool("update", false, "replay fixture'larını mevcut yanıtlarla yeniden kaydet")
+
+const testModel = "gemini-test"
+
+func replayProvider(t *testing.T, name string) Provider {
+	t.Helper()
+	dir := filepath.Join("testdata", "replay", name)
+	if !*update {
+		return NewReplayProvider(dir, testModel)
+	}
+	provider, err := RerecordFixtures(dir, testModel)
+	if err != nil {
+		t.Fatalf("rerecord %s: %v", name, err)
+	}
+	return provider
+}
+
+// Arama sonuçları testdata/search altındaki fixture'lardan gelir
+func fixtureSearch() utils.SearchProvider {
+	return utils.NewFixtureSearchProvider(filepath.Join("testdata", "search"))
+}
+
+type recordingSearch struct {
+	utils.SearchProvider
+	queries []string
+}
+
+func (r *recordingSearch) Search(ctx context.Context, query string) ([]utils.SearchItem, error) {
+	r.queries = append(r.queries, query)
+	return r.SearchProvider.Search(ctx, query)
+}
+
+func testPrompt() models.PromptBody {
+	return models.PromptBody{
+		UserID:        "u-1",
+		Name:          "Ege Turu",
+		Description:   "Sahil boyunca kamp",
+		StartPosition: "İzmir",
+		EndPosition:   "Antalya",
+		StartDate:     "2025-08-01",
+		EndDate:       "2025-08-04",
+	}
+}
+
+func TestTwoStageGenerationReplay(t *testing.T) {
+	service := &AIService{Provider: replayProvider(t, "two_stage"), Search: fixtureSearch()}
+
+	result, err := service.twoStageGeneration(context.Background(), testPrompt())
+	if err != nil {
+		t.Fatalf("twoStageGeneration: %v", err)
+	}
+
+	plan := result.Plan
+	if result.Status != models.PlanStatusOK || len(result.Violations) != 0 {
+		t.Errorf("status = %s, violations = %+v", result.Status, result.Violations)
+	}
+	if len(plan.DailyPlan) != 3 {
+		t.Fatalf("daily_plan length = %d, want 3", len(plan.DailyPlan))
+	}
+	if got := plan.DailyPlan[0].Location.Name; got != "Kuşadası Yat Camping" {
+		t.Errorf("day 1 location = %q", got)
+	}
+}
+
+func TestManagedConversationReplay(t *testing.T) {
+	search := &recordingSearch{SearchProvider: fixtureSearch()}
+	service := &AIService{Provider: replayProvider(t, "function_calls"), Search: search}
+
+	result, err := service.GenerateTripPlanWithFunctionCalls(context.Background(), testPrompt())
+	if err != nil {
+		t.Fatalf("GenerateTripPlanWithFunctionCalls: %v", err)
+	}
+
+	if len(search.queries) != 1 || !strings.Contains(search.queries[0], "kamp") {
+		t.Errorf("search queries = %v, want the model's single query", search.queries)
+	}
+	if result.Status != models.PlanStatusOK {
+		t.Errorf("status = %s, want ok", result.Status)
+	}
+	if plan := result.Plan; plan.Trip.TotalDays != 4 || len(plan.DailyPlan) != 3 {
+		t.Errorf("plan = %+v, want 3 days", plan)
+	}
+}
+
+func TestGenerationFallsBackOnProviderError(t *testing.T) {
+	provider := NewFakeProvider(testModel)
+	provider.Err = errors.New("quota exceeded")
+	service := &AIService{Provider: provider, Search: fixtureSearch()}
+
+	result, err := service.GenerateTripPlan(context.Background(), testPrompt())
+	if err != nil {
+		t.Fatalf("GenerateTripPlan: %v", err)
+	}
+
+	plan := result.Plan
+	if len(plan.DailyPlan) != 3 || plan.DailyPlan[0].Location.Latitude != 39.9334 {
+		t.Errorf("expected fallback plan, got %+v", plan)
+	}
+	if result.Status != models.PlanStatusDegraded || result.DegradedReason != DegradedGenerationFailed {
+		t.Errorf("status = %s (%s), want degraded (%s)", result.Status, result.DegradedReason, DegradedGenerationFailed)
+	}
+	if provider.Calls() != 1 {
+		t.Errorf("provider calls = %d, want 1", provider.Calls())
+	}
+}
+
+func TestStrictModeReturnsErrorInsteadOfFallback(t *testing.T) {
+	provider := NewFakeProvider(testModel, FakeTextResponse("Üzgünüm, plan oluşturamadım."))
+	service := &AIService{Provider: provider, Search: fixtureSearch(), StrictMode: true}
+
+	result, err := service.GenerateTripPlan(context.Background(), testPrompt())
+	if !errors.Is(err, ErrPlanUnavailable) {
+		t.Fatalf("err = %v, result = %+v, want ErrPlanUnavailable", err, result)
+	}
+	if !strings.Contains(err.Error(), DegradedInvalidResponse) {
+		t.Errorf("error should carry the reason: %v", err)
+	}
+}
+
+func TestCancelledContextStopsGeneration(t *testing.T) {
+	provider := NewFakeProvider(testModel, FakeTextResponse("{}"))
+	search := &recordingSearch{SearchProvider: fixtureSearch()}
+	service := &AIService{Provider: provider, Search: search, SearchInterval: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		// İlk aramadan sonraki bekleme sırasında iptal
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	result, err := service.GenerateTripPlan(ctx, testPrompt())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, result = %+v, want context.Canceled", err, result)
+	}
+	if len(search.queries) != 1 {
+		t.Errorf("search queries = %d, want 1 before cancellation", len(search.queries))
+	}
+	if provider.Calls() != 0 {
+		t.Errorf("provider calls = %d, want none after cancellation", provider.Calls())
+	}
+}
+
+func TestRepairLoopFixesViolations(t *testing.T) {
+	service := &AIService{Provider: replayProvider(t, "repair"), Search: fixtureSearch(), MaxRepairRounds: 2}
+
+	result, err := service.twoStageGeneration(context.Background(), testPrompt())
+	if err != nil {
+		t.Fatalf("twoStageGeneration: %v", err)
+	}
+
+	if len(result.Violations) != 0 {
+		t.Errorf("violations after repair: %+v", result.Violations)
+	}
+	if loc := result.Plan.DailyPlan[1].Location; loc.Latitude == 0 {
+		t.Errorf("day 2 still has zero coordinates: %+v", loc)
+	}
+}
+
+func TestRepairLoopKeepsViolationsWhenRoundsExhausted(t *testing.T) {
+	fixtures, err := LoadReplayFixtures(filepath.Join("testdata", "replay", "repair"))
+	if err != nil || len(fixtures) == 0 {
+		t.Fatalf("load fixtures: %v", err)
+	}
+	// Model her turda aynı hatalı planı döndürüyor
+	bad := fixtures[0].Response
+	provider := NewFakeProvider(testModel, bad, bad, bad)
+	service := &AIService{Provider: provider, Search: fixtureSearch(), MaxRepairRounds: 2}
+
+	result, err := service.twoStageGeneration(context.Background(), testPrompt())
+	if err != nil {
+		t.Fatalf("twoStageGeneration: %v", err)
+	}
+
+	if provider.Calls() != 3 {
+		t.Errorf("provider calls = %d, want 1 generation + 2 repairs", provider.Calls())
+	}
+	codes := map[string]bool{}
+	for _, v := range result.Violations {
+		codes[v.Code] = true
+	}
+	if !codes[ViolationZeroCoordinates] || !codes[ViolationDuplicateCampsite] {
+		t.Errorf("violations = %+v", result.Violations)
+	}
+}
+
+func TestMissingNightsAreRegenerated(t *testing.T) {
+	short := validPlan()
+	short.DailyPlan = short.DailyPlan[:2]
+	data, _ := json.Marshal(short)
+	night := `{"day": 3, "date": "2025-08-03", "location": {"name": "Çıralı Sahil Kamp", "latitude": 36.41, "longitude": 30.47}}`
+	provider := NewFakeProvider(testModel, FakeTextResponse(string(data)), FakeTextResponse(night))
+	service := &AIService{Provider: provider, Search: fixtureSearch()}
+
+	result, err := service.twoStageGeneration(context.Background(), testPrompt())
+	if err != nil {
+		t.Fatalf("twoStageGeneration: %v", err)
+	}
+	days := result.Plan.DailyPlan
+	if result.Status != models.PlanStatusOK || len(days) != 3 || days[2].Location.Name != "Çıralı Sahil Kamp" || days[2].Date != "2025-08-03" {
+		t.Errorf("status = %s, days = %+v", result.Status, days)
+	}
+	if len(result.Violations) != 0 {
+		t.Errorf("violations = %+v", result.Violations)
+	}
+
+	// Eksik gece üretilemezse kısa plan döndürülmez
+	provider = NewFakeProvider(testModel, FakeTextResponse(string(data)))
+	service = &AIService{Provider: provider, Search: fixtureSearch(), StrictMode: true}
+	if result, err := service.twoStageGeneration(context.Background(), testPrompt()); !errors.Is(err, ErrPlanUnavailable) || !strings.Contains(err.Error(), DegradedMissingNights) {
+		t.Errorf("err = %v, result = %+v, want ErrPlanUnavailable (%s)", err, result, DegradedMissingNights)
+	}
+}
+
+func TestReplayProviderMissingFixture(t *testing.T) {
+	provider := NewReplayProvider(t.TempDir(), testModel)
+	_, err := provider.Generate(context.Background(), nil, nil)
+	if !errors.Is(err, ErrFixtureNotFound) {
+		t.Fatalf("err = %v, want ErrFixtureNotFound", err)
+	}
+}
+
+func TestGenerateTripPlanSavesRecord(t *testing.T) {
+	store, err := storage.Open(storage.DriverSQLite, filepath.Join(t.TempDir(), "plans.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer store.Close()
+	service := &AIService{Provider: replayProvider(t, "two_stage"), Search: fixtureSearch(), Store: store}
+
+	result, err := service.GenerateTripPlan(context.Background(), testPrompt())
+	if err != nil {
+		t.Fatalf("GenerateTripPlan: %v", err)
+	}
+	if result.ID == "" {
+		t.Fatal("result should carry the stored plan id")
+	}
+
+	record, err := store.GetPlan(context.Background(), result.ID)
+	if err != nil {
+		t.Fatalf("GetPlan: %v", err)
+	}
+	if record.UserID != "u-1" || record.Model != testModel || record.Error != "" {
+		t.Errorf("record = %+v", record)
+	}
+	if record.Result == nil || len(record.Result.Plan.DailyPlan) != 3 {
+		t.Errorf("stored result = %+v", record.Result)
+	}
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"ai-routes-service/internal/geo"
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/storage"
+	"ai-routes-service/internal/vector"
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+const (
+	// Güzergah çizgisine en fazla uzaklık
+	CATALOGUE_CORRIDOR_KM = 50
+	// Prompt'a eklenecek en fazla kamp alanı
+	CATALOGUE_LIMIT = 15
+	// Tek embedding çağrısındaki en fazla kamp alanı
+	EMBEDDING_BATCH_SIZE = 64
+	// Tarif benzerliği sıralamasında doğrulanmış kayıtlara eklenen pay
+	VERIFIED_SIMILARITY_BONUS = 0.05
+)
+
+// CampsiteRetriever yerel katalogdan başlangıç-bitiş koridoru boyunca kamp
+// alanlarını seçer. Doğrulanmış ve güzergaha yakın kayıtlar öne alınır,
+// seçim rotanın tamamına yayılır. Embedder ve indeks varsa gezginin tarifine
+// (PromptBody.Description) anlamca en yakın kayıtlar öne alınır.
+type CampsiteRetriever struct {
+	Catalogue  storage.CampsiteCatalogue
+	CorridorKm float64
+	Limit      int
+	Embedder   Embedder
+	index      *vector.Index
+}
+
+func NewCampsiteRetriever(catalogue storage.CampsiteCatalogue) *CampsiteRetriever {
+	return &CampsiteRetriever{Catalogue: catalogue, CorridorKm: CATALOGUE_CORRIDOR_KM, Limit: CATALOGUE_LIMIT}
+}
+
+type corridorSite struct {
+	site models.Campsite
+	// Güzergaha uzaklık ve güzergah üzerindeki konum (0 = başlangıç, 1 = bitiş)
+	km, t float64
+	// Tarif benzerliği; tarif yoksa 0
+	score float64
+}
+
+// IndexCampsites vektörü olmayan veya eskimiş kayıtları gömer, ardından modelin
+// tüm vektörlerini bellekteki indekse yükler
+func (r *CampsiteRetriever) IndexCampsites(ctx context.Context) error {
+	model := r.Embedder.EmbeddingModel()
+	embedded := 0
+	for {
+		sites, err := r.Catalogue.CampsitesWithoutEmbedding(ctx, model, EMBEDDING_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+		if len(sites) == 0 {
+			break
+		}
+		texts := make([]string, len(sites))
+		for i, site := range sites {
+			texts[i] = campsiteDocument(site)
+		}
+		vectors, err := r.Embedder.EmbedDocuments(ctx, texts)
+		if err != nil {
+			return fmt.Errorf("campsite embedding failed: %w", err)
+		}
+		embeddings := make([]storage.Embedding, len(sites))
+		for i, site := range sites {
+			embeddings[i] = storage.Embedding{CampsiteID: site.ID, Vector: vectors[i], UpdatedAt: site.UpdatedAt}
+		}
+		if err := r.Catalogue.SaveEmbeddings(ctx, model, embeddings); err != nil {
+			return err
+		}
+		embedded += len(sites)
+	}
+
+	embeddings, err := r.Catalogue.Embeddings(ctx, model)
+	if err != nil {
+		return err
+	}
+	index := vector.NewIndex()
+	for _, e := range embeddings {
+		index.Add(e.CampsiteID, e.Vector)
+	}
+	r.index = index
+	log.Printf("🧭 Campsite index: %d vectors (%s), %d newly embedded", index.Len(), model, embedded)
+	return nil
+}
+
+// Kamp alanının gömülecek metni
+func campsiteDocument(site models.Campsite) string {
+	parts := []string{site.Name, site.Description, site.Address}
+	if len(site.Amenities) > 0 {
+		parts = append(parts, strings.Join(site.Amenities, ", "))
+	}
+	if site.Season != "" {
+		parts = append(parts, "sezon: "+site.Season)
+	}
+	return strings.Join(parts, ". ")
+}
+
+// Along from-to koridorundaki kamp alanlarını güzergah sırasıyla döndürür.
+// description boş değilse ve indeks hazırsa sıralama tarif benzerliğine göre yapılır.
+func (r *CampsiteRetriever) Along(ctx context.Context, from, to geo.Point, description string) ([]models.Campsite, error) {
+	sites, err := r.Catalogue.CampsitesInBox(ctx, geo.CorridorBox(from, to, r.CorridorKm), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []corridorSite{}
+	for _, site := range sites {
+		km, t := geo.SegmentProjection(geo.Point{Lat: site.Latitude, Lon: site.Longitude}, from, to)
+		if km <= r.CorridorKm {
+			candidates = append(candidates, corridorSite{site: site, km: km, t: t})
+		}
+	}
+	if r.scoreDescription(ctx, candidates, description) {
+		sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+	} else {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if candidates[i].site.Verified != candidates[j].site.Verified {
+				return candidates[i].site.Verified
+			}
+			return candidates[i].km < candidates[j].km
+		})
+	}
+
+	// Önce her güzergah diliminden en iyi aday, kalan yerler sıralamaya göre
+	limit := r.Limit
+	if limit <= 0 || limit > len(candidates) {
+		limit = len(candidates)
+	}
+	picked := make([]bool, len(candidates))
+	filled := make([]bool, limit)
+	selected := []corridorSite{}
+	for i, c := range candidates {
+		bin := min(int(c.t*float64(limit)), limit-1)
+		if !filled[bin] {
+			filled[bin], picked[i] = true, true
+			selected = append(selected, c)
+		}
+	}
+	for i, c := range candidates {
+		if len(selected) >= limit {
+			break
+		}
+		if !picked[i] {
+			selected = append(selected, c)
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].t < selected[j].t })
+	result := make([]models.Campsite, len(selected))
+	for i, c := range selected {
+		result[i] = c.site
+	}
+	return result, nil
+}
+
+// Adayları tarif benzerliğiyle puanlar; tarif, embedder veya indeks yoksa false
+func (r *CampsiteRetriever) scoreDescription(ctx context.Context, candidates []corridorSite, description string) bool {
+	if strings.TrimSpace(description) == "" || r.Embedder == nil || r.index == nil || len(candidates) == 0 {
+		return false
+	}
+	query, err := r.Embedder.EmbedQuery(ctx, description)
+	if err != nil {
+		log.Printf("⚠️ Description embedding failed: %v", err)
+		return false
+	}
+
+	ids := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		ids[c.site.ID] = true
+	}
+	scores := map[string]float64{}
+	for _, match := range r.index.Search(query, 0, func(id string) bool { return ids[id] }) {
+		scores[match.ID] = match.Score
+	}
+	for i := range candidates {
+		candidates[i].score = scores[candidates[i].site.ID]
+		if candidates[i].site.Verified {
+			candidates[i].score += VERIFIED_SIMILARITY_BONUS
+		}
+	}
+	return true
+}
+
+// Katalogdaki kamp alanlarını modele verilecek metne çevirir
+func catalogueText(sites []models.Campsite, description string) string {
+	var b strings.Builder
+	b.WriteString("KATALOGDAKİ KAMP ALANLARI (güzergah sırasıyla):\n")
+	if description = strings.TrimSpace(description); description != "" {
+		fmt.Fprintf(&b, "Liste gezginin tarifine (%q) en uygun alanlardan seçildi.\n", description)
+	}
+	for _, site := range sites {
+		fmt.Fprintf(&b, "• %s - %s (%.6f, %.6f)", site.Name, site.Address, site.Latitude, site.Longitude)
+		if site.Description != "" {
+			fmt.Fprintf(&b, " | %s", site.Description)
+		}
+		if site.SiteURL != "" {
+			fmt.Fprintf(&b, " %s", site.SiteURL)
+		}
+		if len(site.Amenities) > 0 {
+			fmt.Fprintf(&b, " | olanaklar: %s", strings.Join(site.Amenities, ", "))
+		}
+		if site.Season != "" {
+			fmt.Fprintf(&b, " | sezon: %s", site.Season)
+		}
+		if site.Verified {
+			b.WriteString(" | doğrulanmış")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("Kamp alanlarını öncelikle bu listeden seç ve koordinatlarını aynen kullan.\n")
+	return b.String()
+}
+
+// catalogueContext yapılandırılmışsa iki nokta arasındaki katalog kayıtlarını
+// prompt metni olarak döndürür; katalog yoksa veya sonuç çıkmazsa boş döner
+func (s *AIService) catalogueContext(ctx context.Context, from, to *geo.Point, description string) string {
+	if s.Campsites == nil || from == nil || to == nil {
+		return ""
+	}
+	sites, err := s.Campsites.Along(ctx, *from, *to, description)
+	if err != nil {
+		log.Printf("⚠️ Catalogue lookup failed: %v", err)
+		return ""
+	}
+	if len(sites) == 0 {
+		return ""
+	}
+	log.Printf("🏕️ Catalogue: %d campsites along the route", len(sites))
+	return catalogueText(sites, description)
+}
+
+// Metindeki konumun koordinatı: geocoder varsa onunla, yoksa geçen il merkezi
+func (s *AIService) resolvePosition(ctx context.Context, position string) *geo.Point {
+	if s.Geocoding != nil {
+		if result, ok := s.Geocoding.geocode(ctx, position); ok {
+			return &geo.Point{Lat: result.Latitude, Lon: result.Longitude}
+		}
+	}
+	return endpointPoint(nil, position)
+}
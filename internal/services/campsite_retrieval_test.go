@@ -0,0 +1,111 @@
+package services
+
+import (
+	"ai-routes-service/internal/geo"
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/storage"
+	"context"
+	"testing"
+)
+
+type memoryCatalogue []models.Campsite
+
+func (m memoryCatalogue) UpsertCampsites(ctx context.Context, sites []models.Campsite) (int, error) {
+	return 0, nil
+}
+
+func (m memoryCatalogue) CampsitesInBox(ctx context.Context, box geo.BBox, limit int) ([]models.Campsite, error) {
+	sites := []models.Campsite{}
+	for _, site := range m {
+		if site.Latitude >= box.MinLat && site.Latitude <= box.MaxLat && site.Longitude >= box.MinLon && site.Longitude <= box.MaxLon {
+			sites = append(sites, site)
+		}
+	}
+	return sites, nil
+}
+
+func (m memoryCatalogue) CampsitesWithoutEmbedding(ctx context.Context, model string, limit int) ([]models.Campsite, error) {
+	return nil, nil
+}
+
+func (m memoryCatalogue) SaveEmbeddings(ctx context.Context, model string, embeddings []storage.Embedding) error {
+	return nil
+}
+
+func (m memoryCatalogue) Embeddings(ctx context.Context, model string) ([]storage.Embedding, error) {
+	return nil, nil
+}
+
+func (m memoryCatalogue) Close() error { return nil }
+
+func TestCampsiteRetrieverAlong(t *testing.T) {
+	// İzmir → Antalya koridoru
+	from := geo.Point{Lat: 38.42, Lon: 27.14}
+	to := geo.Point{Lat: 36.89, Lon: 30.71}
+	catalogue := memoryCatalogue{
+		{ID: "antalya", Name: "Antalya Kamp", Latitude: 36.90, Longitude: 30.60, Verified: true},
+		{ID: "izmir", Name: "İzmir Kamp", Latitude: 38.40, Longitude: 27.20},
+		{ID: "denizli", Name: "Denizli Kamp", Latitude: 37.70, Longitude: 29.00, Verified: true},
+		{ID: "denizli-2", Name: "Pamukkale Kamp", Latitude: 37.72, Longitude: 29.10},
+		{ID: "trabzon", Name: "Trabzon Kamp", Latitude: 41.00, Longitude: 39.70, Verified: true},
+		{ID: "bodrum", Name: "Bodrum Kamp", Latitude: 37.03, Longitude: 27.43, Verified: true},
+	}
+
+	retriever := NewCampsiteRetriever(catalogue)
+	retriever.Limit = 3
+	sites, err := retriever.Along(context.Background(), from, to, "")
+	if err != nil {
+		t.Fatalf("Along: %v", err)
+	}
+
+	// Bodrum koridor dışında; her dilimden bir kayıt, doğrulanmışlar önce, güzergah sırasıyla
+	want := []string{"izmir", "denizli", "antalya"}
+	if len(sites) != len(want) {
+		t.Fatalf("sites = %+v, want %v", sites, want)
+	}
+	for i, id := range want {
+		if sites[i].ID != id {
+			t.Errorf("site %d = %s, want %s", i, sites[i].ID, id)
+		}
+	}
+}
+
+func TestCampsiteRetrieverDescription(t *testing.T) {
+	catalogue, err := storage.OpenCatalogue(t.TempDir() + "/catalogue.db")
+	if err != nil {
+		t.Fatalf("OpenCatalogue: %v", err)
+	}
+	defer catalogue.Close()
+	ctx := context.Background()
+
+	// Eğirdir gölü kıyısındaki alan doğrulanmamış ve güzergaha en uzak olanı
+	sites := []models.Campsite{
+		{ID: "denizli", Name: "Pamukkale Kamp", Latitude: 37.70, Longitude: 29.00, Verified: true, Description: "Şehir merkezine yakın, otopark"},
+		{ID: "egirdir", Name: "Eğirdir Kamp", Latitude: 37.87, Longitude: 30.85, Description: "Göl kenarında sessiz bir alan", Amenities: []string{"evcil hayvan", "duş"}},
+		{ID: "burdur", Name: "Burdur Kamp", Latitude: 37.72, Longitude: 30.29, Verified: true},
+	}
+	if _, err := catalogue.UpsertCampsites(ctx, sites); err != nil {
+		t.Fatalf("UpsertCampsites: %v", err)
+	}
+
+	retriever := NewCampsiteRetriever(catalogue)
+	retriever.CorridorKm = 150
+	retriever.Limit = 1
+	retriever.Embedder = NewLocalEmbedder()
+	if err := retriever.IndexCampsites(ctx); err != nil {
+		t.Fatalf("IndexCampsites: %v", err)
+	}
+	if missing, _ := catalogue.CampsitesWithoutEmbedding(ctx, retriever.Embedder.EmbeddingModel(), 0); len(missing) != 0 {
+		t.Errorf("sites without embedding after indexing: %+v", missing)
+	}
+
+	from, to := geo.Point{Lat: 38.42, Lon: 27.14}, geo.Point{Lat: 36.89, Lon: 30.71}
+	plain, err := retriever.Along(ctx, from, to, "")
+	if err != nil || len(plain) != 1 || !plain[0].Verified {
+		t.Fatalf("Along without description = %+v, %v; want a verified site", plain, err)
+	}
+	described, err := retriever.Along(ctx, from, to, "quiet, near a lake, dog friendly")
+	if err != nil || len(described) != 1 || described[0].ID != "egirdir" {
+		t.Fatalf("Along with description = %+v, %v; want egirdir", described, err)
+	}
+}
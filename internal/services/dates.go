@@ -0,0 +1,207 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrInvalidPrompt istek tarihleri okunamadığında veya aralık geçersiz olduğunda döner
+var ErrInvalidPrompt = errors.New("invalid prompt")
+
+// Bir rotada planlanabilecek en fazla gece
+const MAX_TRIP_NIGHTS = 30
+
+// Kabul edilen tarih formatları: ISO 8601 ve Türkiye'de yaygın gün.ay.yıl yazımları
+var dateLayouts = []string{
+	dateLayout,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006/01/02",
+	"02.01.2006",
+	"2.1.2006",
+	"02/01/2006",
+	"2/1/2006",
+	"02-01-2006",
+	"2-1-2006",
+}
+
+var turkishMonths = map[string]time.Month{
+	"ocak": time.January, "şubat": time.February, "mart": time.March,
+	"nisan": time.April, "mayıs": time.May, "haziran": time.June,
+	"temmuz": time.July, "ağustos": time.August, "eylül": time.September,
+	"ekim": time.October, "kasım": time.November, "aralık": time.December,
+}
+
+// ParseDate tarihi gün hassasiyetinde (UTC gece yarısı) okur.
+// "2025-08-01", "2025-08-01T10:00:00+03:00", "01.08.2025", "1/8/2025" ve
+// "1 Ağustos 2025" gibi yazımlar kabul edilir.
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+
+	// "1 Ağustos 2025"
+	if fields := strings.Fields(value); len(fields) == 3 {
+		month, ok := turkishMonths[strings.ToLowerSpecial(unicode.TurkishCase, fields[1])]
+		day, dayErr := strconv.Atoi(fields[0])
+		year, yearErr := strconv.Atoi(fields[2])
+		if ok && dayErr == nil && yearErr == nil {
+			t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+			// 31 Şubat gibi taşan tarihleri reddet
+			if t.Day() == day {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("tarih %q okunamadı, YYYY-MM-DD veya GG.AA.YYYY formatında olmalı", value)
+}
+
+// NormalizePrompt istek tarihlerini doğrular ve YYYY-MM-DD formatına çevirir.
+// Bitiş tarihi son gecenin ertesi günüdür, en az bir gece olmalıdır.
+func NormalizePrompt(prompt models.PromptBody) (models.PromptBody, error) {
+	start, err := ParseDate(prompt.StartDate)
+	if err != nil {
+		return prompt, fmt.Errorf("%w: start_date: %v", ErrInvalidPrompt, err)
+	}
+	end, err := ParseDate(prompt.EndDate)
+	if err != nil {
+		return prompt, fmt.Errorf("%w: end_date: %v", ErrInvalidPrompt, err)
+	}
+
+	nights := nightsBetween(start, end)
+	switch {
+	case nights < 1:
+		return prompt, fmt.Errorf("%w: end_date start_date'den sonra olmalı", ErrInvalidPrompt)
+	case nights > MAX_TRIP_NIGHTS:
+		return prompt, fmt.Errorf("%w: en fazla %d gece planlanabilir, istenen %d", ErrInvalidPrompt, MAX_TRIP_NIGHTS, nights)
+	}
+
+	prompt.StartDate = start.Format(dateLayout)
+	prompt.EndDate = end.Format(dateLayout)
+	if prompt.Preferences, err = normalizePreferences(prompt.Preferences); err != nil {
+		return prompt, err
+	}
+	return prompt, nil
+}
+
+func nightsBetween(start, end time.Time) int {
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// Normalize edilmiş istekteki başlangıç tarihi ve gece sayısı
+func promptNights(prompt models.PromptBody) (time.Time, int, bool) {
+	start, startErr := ParseDate(prompt.StartDate)
+	end, endErr := ParseDate(prompt.EndDate)
+	if startErr != nil || endErr != nil || end.Before(start) {
+		return time.Time{}, 0, false
+	}
+	return start, nightsBetween(start, end), true
+}
+
+// normalizeDays tarih alanlarını modelden almak yerine istekten hesaplar:
+// trip tarihleri ve total_days (bitiş dahil gün sayısı), her kaydın gün
+// numarası ve gecenin tarihi. Kayıt sayısı burada değişmez; düzeltme
+// turlarından sonra completeNights ile gece sayısına getirilir.
+func normalizeDays(prompt models.PromptBody, plan *models.TripPlan) {
+	start, nights, ok := promptNights(prompt)
+	if !ok {
+		return
+	}
+	plan.Trip.StartDate = prompt.StartDate
+	plan.Trip.EndDate = prompt.EndDate
+	plan.Trip.TotalDays = nights + 1
+	for i := range plan.DailyPlan {
+		plan.DailyPlan[i].Day = i + 1
+		plan.DailyPlan[i].Date = start.AddDate(0, 0, i).Format(dateLayout)
+	}
+}
+
+// trimExtraNights düzeltme turlarından sonra hâlâ fazla kayıt varsa planı gece
+// sayısına indirir. Son kayıt bitişe en yakın konak olduğu için korunur,
+// fazlalar ondan öncekilerden atılır. Plan değiştiyse true döner.
+func trimExtraNights(prompt models.PromptBody, plan *models.TripPlan) bool {
+	_, nights, ok := promptNights(prompt)
+	if !ok || nights < 1 || len(plan.DailyPlan) <= nights {
+		return false
+	}
+	last := plan.DailyPlan[len(plan.DailyPlan)-1]
+	plan.DailyPlan = append(plan.DailyPlan[:nights-1:nights-1], last)
+	normalizeDays(prompt, plan)
+	return true
+}
+
+// completeNights planı gece sayısına getirir: fazla kayıtlar atılır, eksik
+// geceler yeniden üretilir. Eksik gece üretilemezse hata döner.
+func (s *AIService) completeNights(ctx context.Context, prompt models.PromptBody, plan *models.TripPlan) ([]models.CoordinateCorrection, error) {
+	if trimExtraNights(prompt, plan) {
+		log.Printf("✂️ Plan trimmed to %d nights", len(plan.DailyPlan))
+		s.measureDistances(ctx, prompt, plan)
+		return nil, nil
+	}
+	return s.fillMissingNights(ctx, prompt, plan)
+}
+
+// fillMissingNights düzeltme turlarından sonra hâlâ eksik gece varsa her birini
+// gün yeniden üretme yoluyla tamamlar. Boş gün en uzun etabın ortasına eklenir ve
+// komşu konaklar sabit tutularak üretilir. Bir gece üretilemezse hata döner; plan
+// eksik gece ile döndürülmez. Yapılan koordinat düzeltmelerini döndürür.
+func (s *AIService) fillMissingNights(ctx context.Context, prompt models.PromptBody, plan *models.TripPlan) ([]models.CoordinateCorrection, error) {
+	_, nights, ok := promptNights(prompt)
+	if !ok {
+		return nil, nil
+	}
+	var corrections []models.CoordinateCorrection
+	for len(plan.DailyPlan) < nights {
+		index := longestLegIndex(plan)
+		log.Printf("➕ Missing night %d/%d, generating day %d", len(plan.DailyPlan)+1, nights, index+1)
+		plan.DailyPlan = slices.Insert(plan.DailyPlan, index, models.DailyPlan{})
+		normalizeDays(prompt, plan)
+
+		updated, correction, err := s.generateDay(ctx, prompt, plan, index+1, "")
+		if err != nil {
+			log.Printf("❌ Missing night could not be generated: %v", err)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("%w: %s", ErrPlanUnavailable, DegradedMissingNights)
+		}
+		*plan = *updated
+		if correction != nil {
+			corrections = append(corrections, *correction)
+		}
+	}
+	return corrections, nil
+}
+
+// Eksik gecenin ekleneceği sıra: en uzun etabın bitiş günü, son etap (bitişe)
+// en uzunsa veya mesafeler bilinmiyorsa planın sonu
+func longestLegIndex(plan *models.TripPlan) int {
+	index, longest := len(plan.DailyPlan), plan.Trip.FinalLegKm
+	for i, daily := range plan.DailyPlan {
+		if daily.DistanceKm > longest {
+			index, longest = i, daily.DistanceKm
+		}
+	}
+	return index
+}
+
+// Modelden her gece için tek kayıt istenir; tarihler sunucuda yine de düzeltilir
+func nightsInstruction(prompt models.PromptBody) string {
+	start, nights, ok := promptNights(prompt)
+	if !ok {
+		return ""
+	}
+	last := start.AddDate(0, 0, nights-1).Format(dateLayout)
+	return fmt.Sprintf("Konaklama: %d gece (%s - %s), daily_plan'da her gece için tam bir kayıt olmalı.", nights, prompt.StartDate, last)
+}
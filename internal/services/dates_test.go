@@ -0,0 +1,63 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"errors"
+	"testing"
+)
+
+func TestParseDate(t *testing.T) {
+	for _, value := range []string{"2025-08-01", " 2025-08-01 ", "2025-08-01T23:30:00+03:00", "01.08.2025", "1.8.2025", "01/08/2025", "01-08-2025", "1 Ağustos 2025", "1 AĞUSTOS 2025"} {
+		got, err := ParseDate(value)
+		if err != nil || got.Format(dateLayout) != "2025-08-01" {
+			t.Errorf("ParseDate(%q) = %v, %v", value, got, err)
+		}
+	}
+	for _, value := range []string{"", "yarın", "32.08.2025", "31 Şubat 2025", "08/31/2025"} {
+		if _, err := ParseDate(value); err == nil {
+			t.Errorf("ParseDate(%q) accepted", value)
+		}
+	}
+}
+
+func TestNormalizePrompt(t *testing.T) {
+	prompt, err := NormalizePrompt(models.PromptBody{StartDate: "01.08.2025", EndDate: "4 Ağustos 2025"})
+	if err != nil || prompt.StartDate != "2025-08-01" || prompt.EndDate != "2025-08-04" {
+		t.Fatalf("NormalizePrompt = %+v, %v", prompt, err)
+	}
+
+	for _, invalid := range []models.PromptBody{
+		{StartDate: "2025-08-01", EndDate: "2025-08-01"},
+		{StartDate: "2025-08-05", EndDate: "2025-08-01"},
+		{StartDate: "2025-08-01", EndDate: "2025-10-01"},
+		{StartDate: "bugün", EndDate: "2025-08-01"},
+	} {
+		if _, err := NormalizePrompt(invalid); !errors.Is(err, ErrInvalidPrompt) {
+			t.Errorf("NormalizePrompt(%s - %s) err = %v", invalid.StartDate, invalid.EndDate, err)
+		}
+	}
+}
+
+func TestNormalizeDaysAndTrim(t *testing.T) {
+	prompt := testPrompt()
+	plan := validPlan()
+	plan.Trip.TotalDays = 7
+	plan.DailyPlan[0].Day, plan.DailyPlan[1].Date = 5, "2025-09-01"
+	normalizeDays(prompt, plan)
+	if plan.Trip.TotalDays != 4 || plan.Trip.StartDate != "2025-08-01" || plan.Trip.EndDate != "2025-08-04" ||
+		plan.DailyPlan[0].Day != 1 || plan.DailyPlan[1].Date != "2025-08-02" {
+		t.Errorf("normalized = %+v", plan)
+	}
+	if violations := ValidatePlan(plan, prompt); len(violations) != 0 {
+		t.Errorf("violations = %+v", violations)
+	}
+
+	// Fazla kayıt atılırken bitişe en yakın son konak korunur
+	plan.DailyPlan = append(plan.DailyPlan, models.DailyPlan{Location: models.Location{Name: "Antalya Kamp"}})
+	if !trimExtraNights(prompt, plan) || len(plan.DailyPlan) != 3 || plan.DailyPlan[2].Location.Name != "Antalya Kamp" || plan.DailyPlan[2].Day != 3 {
+		t.Errorf("trimmed = %+v", plan.DailyPlan)
+	}
+	if trimExtraNights(prompt, plan) {
+		t.Errorf("plan with one entry per night was trimmed")
+	}
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"ai-routes-service/internal/geo"
+	"ai-routes-service/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+// RegenerateDay kayıtlı planın tek bir gününü önceki ve sonraki günlerin
+// konumlarını sabit tutarak yeniden üretir. Sadece o segment için arama yapılır,
+// diğer günler değişmez; sonuç planın yeni sürümü olarak kaydedilir.
+func (s *AIService) RegenerateDay(ctx context.Context, planID string, day int, instruction string) (*models.PlanRevision, error) {
+	started := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, REQUEST_TIMEOUT)
+	defer cancel()
+
+	parent, err := s.loadRevisable(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	plan := parent.Result.Plan
+	if day < 1 || day > len(plan.DailyPlan) {
+		return nil, fmt.Errorf("%w: %d", ErrDayOutOfRange, day)
+	}
+	prompt := parent.Prompt
+	instruction = strings.TrimSpace(instruction)
+	log.Printf("🔁 Regenerating day %d of plan %s", day, parent.ID)
+
+	updated, correction, err := s.generateDay(ctx, prompt, plan, day, instruction)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.PlanResult{Plan: updated, Status: models.PlanStatusOK, Violations: s.validatePlan(updated, prompt)}
+	if correction != nil {
+		result.Corrections = []models.CoordinateCorrection{*correction}
+	}
+	note := fmt.Sprintf("Gün %d yeniden üretildi", day)
+	if instruction != "" {
+		note += ": " + instruction
+	}
+	return s.saveRevision(ctx, parent, note, prompt, result, started)
+}
+
+// generateDay planın day. gününü önceki ve sonraki günlerin konumlarını sabit
+// tutarak üretir; sadece o segment için arama yapılır. Plan değiştirilmez, günü
+// değiştirilmiş bir kopyası ve varsa koordinat düzeltmesi döner.
+func (s *AIService) generateDay(ctx context.Context, prompt models.PromptBody, plan *models.TripPlan, day int, instruction string) (*models.TripPlan, *models.CoordinateCorrection, error) {
+	// Segmentin uçları: komşu günler, yoksa rotanın başı/sonu
+	from, to := prompt.StartPosition, prompt.EndPosition
+	if day > 1 {
+		from = locationLabel(plan.DailyPlan[day-2].Location)
+	}
+	if day < len(plan.DailyPlan) {
+		to = locationLabel(plan.DailyPlan[day].Location)
+	}
+
+	searchResults, err := s.runSearches(ctx, []string{
+		fmt.Sprintf("%s %s arası kamp alanları", from, to),
+		fmt.Sprintf("%s yakınında kamp yerleri koordinat", to),
+	})
+	if ctx.Err() != nil {
+		return nil, nil, ctx.Err()
+	}
+	if err != nil {
+		log.Printf("⚠️ Segment search failed, continuing without: %v", err)
+		searchResults = "Arama yapılamadı, genel bilgilerle gün oluşturulacak."
+	} else {
+		searchResults = summarizeSearchResults(searchResults, 20)
+	}
+	if catalogue := s.catalogueContext(ctx, segmentEnd(ctx, s, plan, day-2, prompt.StartPosition), segmentEnd(ctx, s, plan, day, prompt.EndPosition), prompt.Description); catalogue != "" {
+		searchResults = catalogue + "\n" + searchResults
+	}
+
+	config := planGenerationConfig()
+	config.ResponseSchema = dailyPlanSchema
+	contents := []*genai.Content{
+		genai.NewContentFromText(dayPrompt(prompt, plan, day, instruction, searchResults), genai.RoleUser),
+	}
+
+	updated := *plan
+	updated.DailyPlan = append([]models.DailyPlan(nil), plan.DailyPlan...)
+	var violations []models.PlanViolation
+	var correction *models.CoordinateCorrection
+
+	for round := 0; round <= s.MaxRepairRounds; round++ {
+		if round > 0 {
+			log.Printf("🔧 Day repair round %d/%d: %d violations", round, s.MaxRepairRounds, len(violations))
+			contents = append(contents, genai.NewContentFromText(violationList(violations)+"\nBu hataları düzelterek sadece bu günü aynı JSON formatında tekrar gönder.", genai.RoleUser))
+		}
+
+		resp, err := s.Provider.Generate(ctx, contents, config)
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		if err != nil || resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+			if round > 0 {
+				break
+			}
+			log.Printf("❌ Day generation failed: %v", err)
+			return nil, nil, fmt.Errorf("%w: %s", ErrPlanUnavailable, DegradedGenerationFailed)
+		}
+		contents = append(contents, resp.Candidates[0].Content)
+
+		generated, err := s.parseDailyPlan(resp.Text())
+		if err != nil {
+			if round > 0 {
+				break
+			}
+			log.Printf("⚠️ Day parse failed: %v", err)
+			return nil, nil, fmt.Errorf("%w: %s", ErrPlanUnavailable, DegradedInvalidResponse)
+		}
+		// Gün numarası ve tarihi planın iskeletine aittir, model değiştiremez
+		generated.Day, generated.Date = plan.DailyPlan[day-1].Day, plan.DailyPlan[day-1].Date
+		var generatedCorrection *models.CoordinateCorrection
+		if s.Geocoding != nil {
+			generatedCorrection = s.Geocoding.CorrectDay(ctx, generated)
+		}
+
+		candidate := updated
+		candidate.DailyPlan = append([]models.DailyPlan(nil), updated.DailyPlan...)
+		candidate.DailyPlan[day-1] = *generated
+		s.measureDistances(ctx, prompt, &candidate)
+		s.checkLinks(ctx, &candidate)
+		candidateViolations := violationsForDay(s.validatePlan(&candidate, prompt), day)
+		if round == 0 || len(candidateViolations) <= len(violations) {
+			updated, violations, correction = candidate, candidateViolations, generatedCorrection
+		}
+		if len(violations) == 0 {
+			break
+		}
+	}
+
+	return &updated, correction, nil
+}
+
+func dayPrompt(prompt models.PromptBody, plan *models.TripPlan, day int, instruction string, searchResults string) string {
+	current := plan.DailyPlan[day-1]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "KAMP ROTASI: %s → %s (%s - %s)\n", prompt.StartPosition, prompt.EndPosition, prompt.StartDate, prompt.EndDate)
+	if section := preferencesSection(prompt); section != "" {
+		b.WriteString(section + "\n")
+	}
+	b.WriteString("\n")
+	if current.Location.Name == "" {
+		// Eksik gece: planda bu gün için kayıt yoktu
+		fmt.Fprintf(&b, "Planda %d. gün (%s) için kamp alanı eksik, bu geceyi planla.\n", day, current.Date)
+	} else {
+		fmt.Fprintf(&b, "Sadece %d. günü (%s) yeniden planla. Mevcut kamp alanı: %s\n", day, current.Date, current.Location.Name)
+	}
+	if day > 1 {
+		prev := plan.DailyPlan[day-2].Location
+		fmt.Fprintf(&b, "Önceki gece: %s, %s (%.6f, %.6f)\n", prev.Name, prev.Address, prev.Latitude, prev.Longitude)
+	} else {
+		fmt.Fprintf(&b, "Bu rotanın ilk günü, başlangıç: %s\n", prompt.StartPosition)
+	}
+	if day < len(plan.DailyPlan) {
+		next := plan.DailyPlan[day].Location
+		fmt.Fprintf(&b, "Sonraki gece: %s, %s (%.6f, %.6f)\n", next.Name, next.Address, next.Latitude, next.Longitude)
+	} else {
+		fmt.Fprintf(&b, "Bu rotanın son günü, bitiş: %s\n", prompt.EndPosition)
+	}
+
+	var used []string
+	for i, daily := range plan.DailyPlan {
+		if i != day-1 {
+			used = append(used, daily.Location.Name)
+		}
+	}
+	if len(used) > 0 {
+		fmt.Fprintf(&b, "Diğer günlerde kullanılan kamp alanları (tekrar önerme): %s\n", strings.Join(used, ", "))
+	}
+	if instruction != "" {
+		fmt.Fprintf(&b, "\nKULLANICI İSTEĞİ:\n%s\n", instruction)
+	}
+	fmt.Fprintf(&b, "\nARAMA SONUÇLARI:\n%s\n\n", searchResults)
+	b.WriteString("Önceki ve sonraki gece arasında mantıklı bir konumda gerçek bir kamp alanı seç ve sadece bu günü JSON formatında döndür.")
+	return b.String()
+}
+
+// Segment ucunun koordinatı: komşu gün varsa onun konumu, yoksa rotanın başı/sonu
+func segmentEnd(ctx context.Context, s *AIService, plan *models.TripPlan, index int, position string) *geo.Point {
+	if index >= 0 && index < len(plan.DailyPlan) {
+		if pt := locationPoint(plan.DailyPlan[index].Location); pt != nil {
+			return pt
+		}
+	}
+	return s.resolvePosition(ctx, position)
+}
+
+// Aramalarda kullanılacak konum metni: adres varsa adres, yoksa isim
+func locationLabel(loc models.Location) string {
+	if strings.TrimSpace(loc.Address) != "" {
+		return loc.Address
+	}
+	return loc.Name
+}
+
+// Günün kendi ihlalleri ve sonraki güne yazılan, bu günden başlayan etabın mesafe ihlali
+func violationsForDay(violations []models.PlanViolation, day int) []models.PlanViolation {
+	filtered := []models.PlanViolation{}
+	for _, v := range violations {
+		nextLeg := v.Day == day+1 && (v.Code == ViolationLegTooShort || v.Code == ViolationLegTooLong)
+		if v.Day == day || nextLeg {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// Model yanıtını temizleyip tek bir DailyPlan'a çevirir
+func (s *AIService) parseDailyPlan(response string) (*models.DailyPlan, error) {
+	cleaned := s.cleanJSONResponse(response)
+	if cleaned == "" {
+		return nil, fmt.Errorf("no valid JSON object in response")
+	}
+
+	var daily models.DailyPlan
+	if err := json.Unmarshal([]byte(cleaned), &daily); err != nil {
+		return nil, fmt.Errorf("daily plan JSON does not match schema: %w", err)
+	}
+	return &daily, nil
+}
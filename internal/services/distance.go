@@ -0,0 +1,166 @@
+package services
+
+import (
+	"ai-routes-service/internal/geo"
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/utils"
+	"context"
+	"fmt"
+	"log"
+	"math"
+)
+
+// Mesafe ihlal kodları
+const (
+	ViolationLegTooShort = "leg_too_short"
+	ViolationLegTooLong  = "leg_too_long"
+)
+
+// Günlük etap sınırları. Alt sınır isteğe bağlıdır: kısa rotalarda (İzmir→Kuşadası gibi)
+// hiçbir plan karşılayamayacağı için varsayılan olarak kapalıdır.
+const (
+	DAILY_MIN_KM = 0
+	DAILY_MAX_KM = 400
+)
+
+// DistanceChecker ardışık konaklar arasındaki, başlangıçtan ilk konağa ve son
+// konaktan bitişe olan mesafeleri hesaplar ve günlük sınırları kontrol eder.
+// Router nil ise kuş uçuşu (haversine) mesafe kullanılır. Sınırlardan biri
+// 0 ise o yön kontrol edilmez.
+type DistanceChecker struct {
+	Router utils.Router
+	MinKm  float64
+	MaxKm  float64
+}
+
+func NewDistanceChecker() *DistanceChecker {
+	return &DistanceChecker{MinKm: DAILY_MIN_KM, MaxKm: DAILY_MAX_KM}
+}
+
+// Bir etabın uçları; konumu bilinmeyen uç nil
+type leg struct {
+	day      int
+	from, to *geo.Point
+}
+
+// Başlangıç/bitiş için geocode edilmiş koordinat, yoksa metinde geçen il merkezi kullanılır
+func planLegs(plan *models.TripPlan, prompt models.PromptBody) ([]leg, leg) {
+	start := endpointPoint(plan.Trip.StartCoordinates, prompt.StartPosition)
+	end := endpointPoint(plan.Trip.EndCoordinates, prompt.EndPosition)
+
+	legs := make([]leg, 0, len(plan.DailyPlan))
+	prev := start
+	for _, daily := range plan.DailyPlan {
+		current := locationPoint(daily.Location)
+		legs = append(legs, leg{day: daily.Day, from: prev, to: current})
+		prev = current
+	}
+	return legs, leg{day: len(plan.DailyPlan), from: prev, to: end}
+}
+
+func endpointPoint(coords *models.Coordinates, position string) *geo.Point {
+	if coords != nil {
+		return &geo.Point{Lat: coords.Latitude, Lon: coords.Longitude}
+	}
+	if province, ok := geo.FindProvince(position); ok {
+		return &province.Center
+	}
+	return nil
+}
+
+func locationPoint(loc models.Location) *geo.Point {
+	if loc.Latitude == 0 || loc.Longitude == 0 || !validRange(loc.Latitude, loc.Longitude) {
+		return nil
+	}
+	return &geo.Point{Lat: loc.Latitude, Lon: loc.Longitude}
+}
+
+// Measure her günün DistanceKm alanını ve Trip'in son etap/toplam mesafesini doldurur
+func (d *DistanceChecker) Measure(ctx context.Context, prompt models.PromptBody, plan *models.TripPlan) {
+	legs, final := planLegs(plan, prompt)
+	total := 0.0
+	for i, l := range legs {
+		plan.DailyPlan[i].DistanceKm = d.legKm(ctx, l)
+		total += plan.DailyPlan[i].DistanceKm
+	}
+	plan.Trip.FinalLegKm = d.legKm(ctx, final)
+	plan.Trip.TotalDistanceKm = round1(total + plan.Trip.FinalLegKm)
+}
+
+func (d *DistanceChecker) legKm(ctx context.Context, l leg) float64 {
+	if l.from == nil || l.to == nil {
+		return 0
+	}
+	if d.Router != nil && ctx.Err() == nil {
+		km, err := d.Router.RouteDistanceKm(ctx, *l.from, *l.to)
+		if err == nil {
+			return round1(km)
+		}
+		log.Printf("⚠️ Route distance failed, using haversine: %v", err)
+	}
+	return round1(geo.HaversineKm(*l.from, *l.to))
+}
+
+// Validate Measure ile hesaplanmış mesafeleri sınırlarla karşılaştırır.
+// Son etap son güne yazılır.
+func (d *DistanceChecker) Validate(plan *models.TripPlan, prompt models.PromptBody) []models.PlanViolation {
+	violations := []models.PlanViolation{}
+	minKm, maxKm := d.limits(prompt)
+	legs, final := planLegs(plan, prompt)
+	if minKm > 0 && len(legs) > 0 && legs[0].from != nil && final.to != nil &&
+		geo.HaversineKm(*legs[0].from, *final.to)/float64(len(legs)) < minKm {
+		// Başlangıç-bitiş kuş uçuşu mesafesi gece başına alt sınırın altındaysa sınır karşılanamaz
+		minKm = 0
+	}
+	check := func(l leg, km float64, label string) {
+		if l.from == nil || l.to == nil {
+			return
+		}
+		switch {
+		case maxKm > 0 && km > maxKm:
+			violations = append(violations, models.PlanViolation{Code: ViolationLegTooLong, Day: l.day,
+				Message: fmt.Sprintf("%s %.0f km, günlük en fazla %.0f km olmalı", label, km, maxKm)})
+		case minKm > 0 && km < minKm:
+			violations = append(violations, models.PlanViolation{Code: ViolationLegTooShort, Day: l.day,
+				Message: fmt.Sprintf("%s %.0f km, günlük en az %.0f km olmalı", label, km, minKm)})
+		}
+	}
+
+	for i, l := range legs {
+		label := fmt.Sprintf("%d. gün önceki konaktan", l.day)
+		if i == 0 {
+			label = "Başlangıçtan ilk konağa"
+		}
+		check(l, plan.DailyPlan[i].DistanceKm, label)
+	}
+	// Son etap sadece üst sınıra tabi: bitiş son konağa yakın olabilir
+	if maxKm > 0 && final.from != nil && final.to != nil && plan.Trip.FinalLegKm > maxKm {
+		violations = append(violations, models.PlanViolation{Code: ViolationLegTooLong, Day: final.day,
+			Message: fmt.Sprintf("Son konaktan bitişe %.0f km, günlük en fazla %.0f km olmalı", plan.Trip.FinalLegKm, maxKm)})
+	}
+	return violations
+}
+
+// İstekteki günlük sürüş tercihi sunucu sınırından düşükse üst sınır odur.
+// Alt sınır yeni üst sınıra eşit veya büyükse iki koşul birlikte sağlanamayacağı için kapatılır.
+func (d *DistanceChecker) limits(prompt models.PromptBody) (minKm, maxKm float64) {
+	minKm, maxKm = d.MinKm, d.MaxKm
+	if p := prompt.Preferences; p != nil && p.MaxDailyDriveKm > 0 && (maxKm <= 0 || p.MaxDailyDriveKm < maxKm) {
+		maxKm = p.MaxDailyDriveKm
+		if minKm >= maxKm {
+			minKm = 0
+		}
+	}
+	return minKm, maxKm
+}
+
+func round1(km float64) float64 {
+	return math.Round(km*10) / 10
+}
+
+// measureDistances yapılandırılmışsa plan mesafelerini hesaplar
+func (s *AIService) measureDistances(ctx context.Context, prompt models.PromptBody, plan *models.TripPlan) {
+	if s.Distances != nil {
+		s.Distances.Measure(ctx, prompt, plan)
+	}
+}
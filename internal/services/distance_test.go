@@ -0,0 +1,104 @@
+package services
+
+import (
+	"ai-routes-service/internal/geo"
+	"ai-routes-service/internal/models"
+	"context"
+	"errors"
+	"testing"
+)
+
+type fixedRouter struct {
+	km  float64
+	err error
+}
+
+func (r fixedRouter) RouteDistanceKm(ctx context.Context, from, to geo.Point) (float64, error) {
+	return r.km, r.err
+}
+
+func distancePlan() *models.TripPlan {
+	return &models.TripPlan{DailyPlan: []models.DailyPlan{
+		{Day: 1, Location: models.Location{Name: "Eskişehir Kamp", Latitude: 39.7767, Longitude: 30.5206}},
+		{Day: 2, Location: models.Location{Name: "Kabak Koyu", Latitude: 36.4611, Longitude: 29.1258}},
+		{Day: 3, Location: models.Location{Name: "Kaş Camping", Latitude: 36.2012, Longitude: 29.6312}},
+	}}
+}
+
+func TestDistanceChecker(t *testing.T) {
+	prompt := models.PromptBody{StartPosition: "Ankara", EndPosition: "Antalya"}
+	plan := distancePlan()
+	checker := NewDistanceChecker()
+	checker.Measure(context.Background(), prompt, plan)
+
+	// Ankara → Eskişehir ~200 km, Eskişehir → Kabak ~390 km, Kabak → Kaş ~53 km
+	for i, want := range []float64{200, 390, 53} {
+		if got := plan.DailyPlan[i].DistanceKm; got < want-15 || got > want+15 {
+			t.Errorf("day %d distance = %.1f, want ~%.0f", i+1, got, want)
+		}
+	}
+	if plan.Trip.FinalLegKm == 0 || plan.Trip.TotalDistanceKm <= plan.DailyPlan[1].DistanceKm {
+		t.Errorf("trip distances = %.1f / %.1f", plan.Trip.FinalLegKm, plan.Trip.TotalDistanceKm)
+	}
+
+	checker.MinKm = 100
+	checker.MaxKm = 300
+	violations := checker.Validate(plan, prompt)
+	if len(violations) != 2 || violations[0].Code != ViolationLegTooLong || violations[0].Day != 2 ||
+		violations[1].Code != ViolationLegTooShort || violations[1].Day != 3 {
+		t.Errorf("violations = %+v", violations)
+	}
+	if got := violationsForDay(violations, 1); len(got) != 1 || got[0].Day != 2 {
+		t.Errorf("violationsForDay(1) = %+v, want the leg starting at day 1", got)
+	}
+}
+
+func TestDistanceCheckerRouter(t *testing.T) {
+	prompt := models.PromptBody{StartPosition: "Ankara", EndPosition: "Antalya"}
+	plan := distancePlan()
+	NewDistanceChecker().Measure(context.Background(), prompt, plan)
+	haversine := plan.DailyPlan[0].DistanceKm
+
+	checker := &DistanceChecker{Router: fixedRouter{km: 250.04}}
+	checker.Measure(context.Background(), prompt, plan)
+	if plan.DailyPlan[0].DistanceKm != 250 {
+		t.Errorf("routed distance = %.2f, want 250", plan.DailyPlan[0].DistanceKm)
+	}
+
+	checker.Router = fixedRouter{err: errors.New("no route")}
+	checker.Measure(context.Background(), prompt, plan)
+	if plan.DailyPlan[0].DistanceKm != haversine {
+		t.Errorf("fallback distance = %.1f, want haversine %.1f", plan.DailyPlan[0].DistanceKm, haversine)
+	}
+
+	// Konumu bilinmeyen uçlar ölçülmez ve kontrol edilmez
+	unknown := &models.TripPlan{DailyPlan: []models.DailyPlan{{Day: 1, Location: models.Location{Name: "Kamp"}}}}
+	checker.Measure(context.Background(), models.PromptBody{StartPosition: "Ev"}, unknown)
+	if unknown.DailyPlan[0].DistanceKm != 0 || len(NewDistanceChecker().Validate(unknown, prompt)) != 0 {
+		t.Errorf("unknown leg measured: %+v", unknown.DailyPlan[0])
+	}
+}
+
+func TestDistanceCheckerShortTrip(t *testing.T) {
+	// İzmir → Kuşadası ~70 km, üç gece
+	prompt := models.PromptBody{StartPosition: "İzmir", EndPosition: "Aydın"}
+	plan := &models.TripPlan{
+		Trip: models.Trip{StartCoordinates: &models.Coordinates{Latitude: 38.4192, Longitude: 27.1287},
+			EndCoordinates: &models.Coordinates{Latitude: 37.8579, Longitude: 27.2610}},
+		DailyPlan: []models.DailyPlan{
+			{Day: 1, Location: models.Location{Name: "Urla Kamp", Latitude: 38.3228, Longitude: 26.7648}},
+			{Day: 2, Location: models.Location{Name: "Seferihisar Kamp", Latitude: 38.1962, Longitude: 26.8387}},
+			{Day: 3, Location: models.Location{Name: "Pamucak Kamp", Latitude: 37.9411, Longitude: 27.2728}},
+		}}
+	checker := NewDistanceChecker()
+	checker.Measure(context.Background(), prompt, plan)
+	if violations := checker.Validate(plan, prompt); len(violations) != 0 {
+		t.Errorf("default config violations = %+v", violations)
+	}
+
+	// Açıkça istenen alt sınır da gece başına kuş uçuşu mesafe ondan kısaysa uygulanmaz
+	checker.MinKm = 200
+	if violations := checker.Validate(plan, prompt); len(violations) != 0 {
+		t.Errorf("unreachable minimum enforced: %+v", violations)
+	}
+}
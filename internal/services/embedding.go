@@ -0,0 +1,246 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode"
+
+	"google.golang.org/genai"
+)
+
+// Embedder metinleri anlamsal arama için vektöre çevirir. Dokümanlar (kamp
+// alanı tanımları) ve sorgular (gezgin tarifi) bazı modellerde farklı görev
+// tipleriyle gömülür.
+type Embedder interface {
+	// EmbeddingModel vektörlerin hangi modelle üretildiği; saklanan vektörler bu isimle ayrılır
+	EmbeddingModel() string
+	EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error)
+	EmbedQuery(ctx context.Context, text string) ([]float32, error)
+}
+
+// Yerel embedder vektör boyutu
+const LOCAL_EMBEDDING_DIM = 256
+
+// LocalEmbedder API gerektirmeyen, kelime ve kavram özetlerini hash'leyen basit
+// bir embedding modeli. Gerçek bir modelin yerini tutar: aynı kavramın Türkçe ve
+// İngilizce karşılıkları (göl/lake, köpek/dog) aynı boyuta düşer, böylece
+// "quiet, near a lake, dog friendly" tarifi OSM'den gelen Türkçe kayıtlarla eşleşir.
+type LocalEmbedder struct {
+	Dim int
+}
+
+func NewLocalEmbedder() *LocalEmbedder {
+	return &LocalEmbedder{Dim: LOCAL_EMBEDDING_DIM}
+}
+
+// Kavram → anahtar kelime kökleri (küçük harf, Türkçe karakterler sadeleştirilmiş)
+var embeddingConcepts = map[string][]string{
+	"lake":     {"gol", "lake", "baraj", "reservoir"},
+	"sea":      {"deniz", "sea", "beach", "plaj", "sahil", "koy", "bay", "coast"},
+	"river":    {"nehir", "river", "dere", "cay", "stream", "irmak"},
+	"forest":   {"orman", "forest", "wood", "agac", "tree", "pine"},
+	"mountain": {"dag", "mountain", "yayla", "plateau", "zirve", "highland"},
+	"quiet":    {"sessiz", "quiet", "sakin", "calm", "huzur", "peaceful", "tenha", "secluded"},
+	"pets":     {"kopek", "dog", "evcil", "pet", "kedi", "cat"},
+	"family":   {"aile", "family", "cocuk", "child", "kid", "oyun", "playground"},
+	"shower":   {"dus", "shower", "sicak"},
+	"power":    {"elektrik", "power", "electric", "priz"},
+	"wifi":     {"wifi", "internet", "wlan"},
+	"caravan":  {"karavan", "caravan", "motorhome", "camper", "rv"},
+	"tent":     {"cadir", "tent"},
+	"pool":     {"havuz", "pool"},
+	"hiking":   {"yuruyus", "hike", "hiking", "trek", "trail", "parkur"},
+}
+
+var asciiFold = strings.NewReplacer("ı", "i", "ğ", "g", "ü", "u", "ş", "s", "ö", "o", "ç", "c", "â", "a", "î", "i", "û", "u")
+
+func (e *LocalEmbedder) EmbeddingModel() string {
+	return fmt.Sprintf("local-hash-%d", e.Dim)
+}
+
+func (e *LocalEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = e.embed(text)
+	}
+	return vectors, nil
+}
+
+func (e *LocalEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	return e.embed(text), nil
+}
+
+func (e *LocalEmbedder) embed(text string) []float32 {
+	v := make([]float32, e.Dim)
+	text = asciiFold.Replace(strings.ToLower(strings.ReplaceAll(text, "İ", "i")))
+	words := strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	for _, word := range words {
+		if len(word) < 2 {
+			continue
+		}
+		// Kavramlar kelimenin kendisinden ağır basar
+		for concept, stems := range embeddingConcepts {
+			for _, stem := range stems {
+				if matchesStem(word, stem) {
+					v[e.bucket("concept:"+concept)] += 3
+					break
+				}
+			}
+		}
+		v[e.bucket("word:"+word)]++
+	}
+	return v
+}
+
+// Kısa kökler (göl, dağ, dog) yalnızca yalın halde veya yaygın eklerle eşleşir,
+// böylece "doğal" köpek, "season" deniz sayılmaz
+var shortStemSuffixes = []string{"", "s", "u", "i", "e", "a", "de", "da", "un", "in", "ler", "lar", "leri", "lari"}
+
+func matchesStem(word, stem string) bool {
+	if len(stem) > 3 {
+		return strings.HasPrefix(word, stem)
+	}
+	suffix, ok := strings.CutPrefix(word, stem)
+	return ok && slices.Contains(shortStemSuffixes, suffix)
+}
+
+func (e *LocalEmbedder) bucket(feature string) int {
+	h := fnv.New32a()
+	h.Write([]byte(feature))
+	return int(h.Sum32() % uint32(e.Dim))
+}
+
+// GeminiEmbedder Gemini embedding API'si (örn. text-embedding-004)
+type GeminiEmbedder struct {
+	Client *genai.Client
+	Model  string
+}
+
+func NewGeminiEmbedder(apiKey string, model string) (*GeminiEmbedder, error) {
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{APIKey: apiKey})
+	if err != nil {
+		return nil, err
+	}
+	return &GeminiEmbedder{Client: client, Model: model}, nil
+}
+
+func (e *GeminiEmbedder) EmbeddingModel() string {
+	return e.Model
+}
+
+func (e *GeminiEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	return e.embed(ctx, texts, "RETRIEVAL_DOCUMENT")
+}
+
+func (e *GeminiEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	vectors, err := e.embed(ctx, []string{text}, "RETRIEVAL_QUERY")
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+func (e *GeminiEmbedder) embed(ctx context.Context, texts []string, taskType string) ([][]float32, error) {
+	contents := make([]*genai.Content, len(texts))
+	for i, text := range texts {
+		contents[i] = genai.NewContentFromText(text, genai.RoleUser)
+	}
+	resp, err := e.Client.Models.EmbedContent(ctx, e.Model, contents, &genai.EmbedContentConfig{TaskType: taskType})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("gemini embedding: %d vectors for %d texts", len(resp.Embeddings), len(texts))
+	}
+	vectors := make([][]float32, len(texts))
+	for i, embedding := range resp.Embeddings {
+		vectors[i] = embedding.Values
+	}
+	return vectors, nil
+}
+
+// OpenAIEmbedder OpenAI uyumlu /embeddings API'si (OpenAI, Ollama, vLLM vb.)
+type OpenAIEmbedder struct {
+	BaseURL    string
+	APIKey     string
+	Model      string
+	HTTPClient *http.Client
+}
+
+func NewOpenAIEmbedder(baseURL string, apiKey string, model string) *OpenAIEmbedder {
+	return &OpenAIEmbedder{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		APIKey:     apiKey,
+		Model:      model,
+		HTTPClient: &http.Client{Timeout: REQUEST_TIMEOUT},
+	}
+}
+
+func (e *OpenAIEmbedder) EmbeddingModel() string {
+	return e.Model
+}
+
+func (e *OpenAIEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	vectors, err := e.EmbedDocuments(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+func (e *OpenAIEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	payload, err := json.Marshal(map[string]any{"model": e.Model, "input": texts})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.BaseURL+"/embeddings", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.APIKey)
+	}
+
+	resp, err := e.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openai embedding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("openai embedding response read failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openai embedding API error: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var embedResp struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &embedResp); err != nil {
+		return nil, fmt.Errorf("openai embedding response decode failed: %w", err)
+	}
+	if len(embedResp.Data) != len(texts) {
+		return nil, fmt.Errorf("openai embedding: %d vectors for %d texts", len(embedResp.Data), len(texts))
+	}
+	vectors := make([][]float32, len(texts))
+	for _, item := range embedResp.Data {
+		if item.Index < 0 || item.Index >= len(texts) {
+			return nil, fmt.Errorf("openai embedding: invalid index %d", item.Index)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	return vectors, nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/genai"
+)
+
+// FakeProvider önceden verilen yanıtları sırayla döndüren deterministik Provider.
+// Testlerde ve replay fixture'larını yeniden kaydederken kullanılır.
+type FakeProvider struct {
+	Model     string
+	Responses []*genai.GenerateContentResponse
+	// Err set edilirse her çağrıda bu hata döner
+	Err error
+
+	mu       sync.Mutex
+	calls    int
+	requests [][]*genai.Content
+}
+
+func NewFakeProvider(model string, responses ...*genai.GenerateContentResponse) *FakeProvider {
+	return &FakeProvider{Model: model, Responses: responses}
+}
+
+// FakeTextResponse tek metin parçalı bir model yanıtı oluşturur
+func FakeTextResponse(text string) *genai.GenerateContentResponse {
+	return &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{
+			Content:      genai.NewContentFromText(text, genai.RoleModel),
+			FinishReason: genai.FinishReasonStop,
+		}},
+	}
+}
+
+// FakeFunctionCallResponse tek function call içeren bir model yanıtı oluşturur
+func FakeFunctionCallResponse(name string, args map[string]any) *genai.GenerateContentResponse {
+	return &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{
+			Content:      genai.NewContentFromFunctionCall(name, args, genai.RoleModel),
+			FinishReason: genai.FinishReasonStop,
+		}},
+	}
+}
+
+func (p *FakeProvider) ModelName() string {
+	return p.Model
+}
+
+func (p *FakeProvider) Generate(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = append(p.requests, contents)
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	if p.calls >= len(p.Responses) {
+		return nil, fmt.Errorf("fake provider: no response left for call %d", p.calls+1)
+	}
+
+	resp := p.Responses[p.calls]
+	p.calls++
+	return resp, nil
+}
+
+func (p *FakeProvider) GenerateWithTools(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, tools []*genai.Tool) (*genai.GenerateContentResponse, error) {
+	return p.Generate(ctx, contents, withTools(config, tools))
+}
+
+func (p *FakeProvider) CountTokens(ctx context.Context, contents []*genai.Content) (int32, error) {
+	return estimateTokens(contents), nil
+}
+
+// Calls şimdiye kadar yapılan Generate çağrısı sayısını döndürür
+func (p *FakeProvider) Calls() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.requests)
+}
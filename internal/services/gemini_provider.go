@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// GeminiProvider Google Gemini API'si üzerinden çalışan Provider
+type GeminiProvider struct {
+	Client *genai.Client
+	Model  string
+}
+
+func NewGeminiProvider(apiKey string, model string) (*GeminiProvider, error) {
+	ctx := context.Background()
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{APIKey: apiKey})
+	if err != nil {
+		return nil, err
+	}
+	return &GeminiProvider{Client: client, Model: model}, nil
+}
+
+func (p *GeminiProvider) ModelName() string {
+	return p.Model
+}
+
+func (p *GeminiProvider) Generate(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	return p.Client.Models.GenerateContent(ctx, p.Model, contents, config)
+}
+
+func (p *GeminiProvider) GenerateStream(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, onText func(text string)) (*genai.GenerateContentResponse, error) {
+	var text strings.Builder
+	var last *genai.GenerateContentResponse
+	for chunk, err := range p.Client.Models.GenerateContentStream(ctx, p.Model, contents, config) {
+		if err != nil {
+			return nil, err
+		}
+		last = chunk
+		if chunkText := chunk.Text(); chunkText != "" {
+			text.WriteString(chunkText)
+			onText(text.String())
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+
+	// Parçaları tek bir aday içeriğinde birleştir
+	resp := *last
+	candidate := &genai.Candidate{Content: genai.NewContentFromText(text.String(), genai.RoleModel)}
+	if len(last.Candidates) > 0 && last.Candidates[0] != nil {
+		candidate.FinishReason = last.Candidates[0].FinishReason
+	}
+	resp.Candidates = []*genai.Candidate{candidate}
+	return &resp, nil
+}
+
+func (p *GeminiProvider) GenerateWithTools(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, tools []*genai.Tool) (*genai.GenerateContentResponse, error) {
+	return p.Generate(ctx, contents, withTools(config, tools))
+}
+
+func (p *GeminiProvider) CountTokens(ctx context.Context, contents []*genai.Content) (int32, error) {
+	resp, err := p.Client.Models.CountTokens(ctx, p.Model, contents, nil)
+	if err != nil {
+		return 0, err
+	}
+	return resp.TotalTokens, nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"ai-routes-service/internal/geo"
+	"ai-routes-service/internal/models"
+	"fmt"
+)
+
+// Coğrafi ihlal kodları
+const (
+	ViolationOutsideRegion  = "outside_region"
+	ViolationSwappedCoords  = "swapped_coordinates"
+	ViolationFarFromAddress = "far_from_address"
+)
+
+const (
+	// Kaba bölge sınırı için kıyı toleransı
+	GEO_REGION_TOLERANCE_KM = 10
+	// Koordinatın adreste geçen il merkezine en fazla uzaklığı
+	GEO_CITY_RADIUS_KM = 200
+)
+
+// GeoValidator plan koordinatlarının bölge içinde, doğru sırada ve adreste
+// geçen ile yakın olduğunu kontrol eder
+type GeoValidator struct {
+	Region       geo.Polygon
+	ToleranceKm  float64
+	CityRadiusKm float64
+}
+
+func NewGeoValidator(region geo.Polygon) *GeoValidator {
+	return &GeoValidator{Region: region, ToleranceKm: GEO_REGION_TOLERANCE_KM, CityRadiusKm: GEO_CITY_RADIUS_KM}
+}
+
+// Validate her gün için coğrafi ihlalleri döndürür. Sıfır veya aralık dışı
+// koordinatlar ValidatePlan tarafından raporlandığı için atlanır.
+func (g *GeoValidator) Validate(plan *models.TripPlan) []models.PlanViolation {
+	violations := []models.PlanViolation{}
+	for _, daily := range plan.DailyPlan {
+		loc := daily.Location
+		if loc.Latitude == 0 || loc.Longitude == 0 || !validRange(loc.Latitude, loc.Longitude) {
+			continue
+		}
+		pt := geo.Point{Lat: loc.Latitude, Lon: loc.Longitude}
+
+		if len(g.Region) > 0 && !g.Region.ContainsWithin(pt, g.ToleranceKm) {
+			swapped := geo.Point{Lat: loc.Longitude, Lon: loc.Latitude}
+			if validRange(swapped.Lat, swapped.Lon) && g.Region.ContainsWithin(swapped, g.ToleranceKm) {
+				violations = append(violations, models.PlanViolation{Code: ViolationSwappedCoords, Day: daily.Day,
+					Message: fmt.Sprintf("%s için enlem/boylam yer değiştirmiş görünüyor (%.6f, %.6f)", loc.Name, loc.Latitude, loc.Longitude)})
+			} else {
+				violations = append(violations, models.PlanViolation{Code: ViolationOutsideRegion, Day: daily.Day,
+					Message: fmt.Sprintf("%s koordinatları (%.6f, %.6f) bölge dışında veya denizde", loc.Name, loc.Latitude, loc.Longitude)})
+			}
+			continue
+		}
+
+		if province, ok := geo.FindProvince(loc.Address); ok {
+			if d := geo.HaversineKm(pt, province.Center); d > g.CityRadiusKm {
+				violations = append(violations, models.PlanViolation{Code: ViolationFarFromAddress, Day: daily.Day,
+					Message: fmt.Sprintf("%s koordinatları adresteki ile (%s) %.0f km uzakta", loc.Name, province.Name, d)})
+			}
+		}
+	}
+	return violations
+}
+
+func validRange(lat, lon float64) bool {
+	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"ai-routes-service/internal/geo"
+	"ai-routes-service/internal/models"
+	"testing"
+)
+
+func TestGeoValidator(t *testing.T) {
+	tests := []struct {
+		name string
+		loc  models.Location
+		want string
+	}{
+		{"valid", models.Location{Name: "Kaş Camping", Address: "Andifli Mah., Kaş/Antalya", Latitude: 36.201234, Longitude: 29.631234}, ""},
+		{"swapped", models.Location{Name: "Kaş Camping", Latitude: 29.631234, Longitude: 36.201234}, ViolationSwappedCoords},
+		{"in the sea", models.Location{Name: "Kaş Camping", Latitude: 35.2, Longitude: 31.0}, ViolationOutsideRegion},
+		{"far from address", models.Location{Name: "Kaş Camping", Address: "Kaş, Antalya", Latitude: 41.01, Longitude: 28.98}, ViolationFarFromAddress},
+		{"zero is left to ValidatePlan", models.Location{Name: "Kaş Camping"}, ""},
+	}
+
+	validator := NewGeoValidator(geo.Turkey)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &models.TripPlan{DailyPlan: []models.DailyPlan{{Day: 1, Location: tt.loc}}}
+			violations := validator.Validate(plan)
+			switch {
+			case tt.want == "" && len(violations) != 0:
+				t.Errorf("violations = %+v, want none", violations)
+			case tt.want != "" && (len(violations) != 1 || violations[0].Code != tt.want):
+				t.Errorf("violations = %+v, want %s", violations, tt.want)
+			}
+		})
+	}
+}
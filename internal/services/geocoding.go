@@ -0,0 +1,199 @@
+package services
+
+import (
+	"ai-routes-service/internal/geo"
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/utils"
+	"context"
+	"errors"
+	"log"
+	"strings"
+)
+
+const (
+	// İsimle bulunan konum ile model koordinatı arasındaki kabul edilebilir fark
+	GEOCODE_THRESHOLD_KM = 3
+	// Sadece adresle bulunan konum ilçe/il merkezi olabileceği için daha geniş eşik
+	GEOCODE_ADDRESS_THRESHOLD_KM = 25
+)
+
+// GeoCorrector kamp alanı ve rota uçlarını geocoder ile bulur; model
+// koordinatı bulunan konumdan eşikten fazla uzaksa koordinatı değiştirir
+type GeoCorrector struct {
+	Geocoder           utils.Geocoder
+	ThresholdKm        float64
+	AddressThresholdKm float64
+}
+
+func NewGeoCorrector(geocoder utils.Geocoder) *GeoCorrector {
+	return &GeoCorrector{
+		Geocoder:           geocoder,
+		ThresholdKm:        GEOCODE_THRESHOLD_KM,
+		AddressThresholdKm: GEOCODE_ADDRESS_THRESHOLD_KM,
+	}
+}
+
+// Kamp alanı sayılan OSM tipleri; isim aramasında bu adaylar önce seçilir
+var campsiteTypes = map[string]bool{"camp_site": true, "caravan_site": true}
+
+// GeocodeMemo bir isteğin düzeltme turları boyunca geocode sonuçlarını tutar;
+// ismi ve adresi değişmeyen günler tekrar geocode edilmez. Bulunamayan sorgular nil olarak tutulur.
+type GeocodeMemo map[string]*utils.GeocodeResult
+
+// Correct planın tüm günlerini düzeltir ve yapılan değişiklikleri döndürür. memo nil olabilir.
+func (g *GeoCorrector) Correct(ctx context.Context, plan *models.TripPlan, memo GeocodeMemo) []models.CoordinateCorrection {
+	corrections := []models.CoordinateCorrection{}
+	for i := range plan.DailyPlan {
+		if ctx.Err() != nil {
+			break
+		}
+		if correction := g.correctDay(ctx, &plan.DailyPlan[i], memo); correction != nil {
+			corrections = append(corrections, *correction)
+		}
+	}
+	return corrections
+}
+
+// CorrectDay önce "isim, il" ile, bulunamazsa adresle arar. İsim aramasında
+// geocoder birden fazla aday döndürebiliyorsa kamp alanı, yoksa turizm sınıfındaki
+// aday tercih edilir. Koordinat değiştirilmediyse nil döner.
+func (g *GeoCorrector) CorrectDay(ctx context.Context, daily *models.DailyPlan) *models.CoordinateCorrection {
+	return g.correctDay(ctx, daily, nil)
+}
+
+func (g *GeoCorrector) correctDay(ctx context.Context, daily *models.DailyPlan, memo GeocodeMemo) *models.CoordinateCorrection {
+	loc := &daily.Location
+	type attempt struct {
+		query     string
+		threshold float64
+		byName    bool
+	}
+	var attempts []attempt
+	if name := strings.TrimSpace(loc.Name); name != "" {
+		if province, ok := geo.FindProvince(loc.Address); ok {
+			name += ", " + province.Name
+		}
+		attempts = append(attempts, attempt{name, g.ThresholdKm, true})
+	}
+	if address := strings.TrimSpace(loc.Address); address != "" {
+		attempts = append(attempts, attempt{address, g.AddressThresholdKm, false})
+	}
+
+	for _, a := range attempts {
+		result, ok := g.memoized(ctx, a.query, a.byName, memo)
+		if !ok {
+			continue
+		}
+		from := models.Coordinates{Latitude: loc.Latitude, Longitude: loc.Longitude}
+		to := models.Coordinates{Latitude: result.Latitude, Longitude: result.Longitude}
+		distance := 0.0
+		if loc.Latitude != 0 && loc.Longitude != 0 && validRange(loc.Latitude, loc.Longitude) {
+			distance = geo.HaversineKm(geo.Point{Lat: from.Latitude, Lon: from.Longitude}, geo.Point{Lat: to.Latitude, Lon: to.Longitude})
+			if distance <= a.threshold {
+				return nil
+			}
+		}
+		log.Printf("📍 Day %d: %s koordinatı düzeltildi (%.6f, %.6f) → (%.6f, %.6f)", daily.Day, loc.Name, from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+		loc.Latitude, loc.Longitude = to.Latitude, to.Longitude
+		return &models.CoordinateCorrection{Day: daily.Day, Name: loc.Name, Query: a.query, From: from, To: to, DistanceKm: distance}
+	}
+	return nil
+}
+
+// LocateEndpoints rotanın başlangıç ve bitiş konumlarını geocode eder
+func (g *GeoCorrector) LocateEndpoints(ctx context.Context, prompt models.PromptBody, plan *models.TripPlan) {
+	g.locateEndpoints(ctx, prompt, plan, nil)
+}
+
+func (g *GeoCorrector) locateEndpoints(ctx context.Context, prompt models.PromptBody, plan *models.TripPlan, memo GeocodeMemo) {
+	if result, ok := g.memoized(ctx, prompt.StartPosition, false, memo); ok {
+		plan.Trip.StartCoordinates = &models.Coordinates{Latitude: result.Latitude, Longitude: result.Longitude}
+	}
+	if result, ok := g.memoized(ctx, prompt.EndPosition, false, memo); ok {
+		plan.Trip.EndCoordinates = &models.Coordinates{Latitude: result.Latitude, Longitude: result.Longitude}
+	}
+}
+
+// Hata olmadıkça sonuç (bulunamadı dahil) memo'ya yazılır
+func (g *GeoCorrector) memoized(ctx context.Context, query string, byName bool, memo GeocodeMemo) (*utils.GeocodeResult, bool) {
+	key := "address:" + query
+	if byName {
+		key = "name:" + query
+	}
+	if result, ok := memo[key]; ok {
+		return result, result != nil
+	}
+
+	var result *utils.GeocodeResult
+	var err error
+	if candidates, ok := g.Geocoder.(utils.CandidateGeocoder); ok && byName {
+		result, err = g.geocodeCandidates(ctx, candidates, query)
+	} else {
+		result, err = g.geocodeQuery(ctx, query)
+	}
+	if memo != nil && (err == nil || errors.Is(err, utils.ErrNoGeocodeResult)) {
+		memo[key] = result
+	}
+	return result, err == nil
+}
+
+// Kamp alanı tipindeki ilk aday, yoksa turizm sınıfındaki ilk aday, o da yoksa ilk aday
+func (g *GeoCorrector) geocodeCandidates(ctx context.Context, geocoder utils.CandidateGeocoder, query string) (*utils.GeocodeResult, error) {
+	if err := queryable(ctx, query); err != nil {
+		return nil, err
+	}
+	results, err := geocoder.GeocodeCandidates(ctx, query)
+	if err == nil && len(results) == 0 {
+		err = utils.ErrNoGeocodeResult
+	}
+	if err != nil {
+		logGeocodeError(ctx, query, err)
+		return nil, err
+	}
+	for i := range results {
+		if campsiteTypes[results[i].Type] {
+			return &results[i], nil
+		}
+	}
+	for i := range results {
+		if results[i].Class == "tourism" {
+			return &results[i], nil
+		}
+	}
+	return &results[0], nil
+}
+
+// Geocoder hataları planı bozmaz, sadece loglanır
+func (g *GeoCorrector) geocode(ctx context.Context, query string) (*utils.GeocodeResult, bool) {
+	result, err := g.geocodeQuery(ctx, query)
+	return result, err == nil
+}
+
+func (g *GeoCorrector) geocodeQuery(ctx context.Context, query string) (*utils.GeocodeResult, error) {
+	if err := queryable(ctx, query); err != nil {
+		return nil, err
+	}
+	result, err := g.Geocoder.Geocode(ctx, query)
+	if err != nil {
+		logGeocodeError(ctx, query, err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// Boş sorgu sonuçsuz sayılır; iptal edilmiş ctx memo'ya yazılmasın diye ctx hatası döner
+func queryable(ctx context.Context, query string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(query) == "" {
+		return utils.ErrNoGeocodeResult
+	}
+	return nil
+}
+
+func logGeocodeError(ctx context.Context, query string, err error) {
+	if !errors.Is(err, utils.ErrNoGeocodeResult) && ctx.Err() == nil {
+		log.Printf("⚠️ Geocode failed for %q: %v", query, err)
+	}
+}
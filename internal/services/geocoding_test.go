@@ -0,0 +1,126 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/utils"
+	"context"
+	"testing"
+)
+
+type mapGeocoder map[string]utils.GeocodeResult
+
+func (m mapGeocoder) Geocode(ctx context.Context, query string) (*utils.GeocodeResult, error) {
+	if result, ok := m[query]; ok {
+		return &result, nil
+	}
+	return nil, utils.ErrNoGeocodeResult
+}
+
+func TestGeoCorrector(t *testing.T) {
+	geocoder := mapGeocoder{
+		"Kaş Camping, Antalya":   {Latitude: 36.2012, Longitude: 29.6312},
+		"Olimpos, Kumluca":       {Latitude: 36.3958, Longitude: 30.4727},
+		"Ankara":                 {Latitude: 39.9208, Longitude: 32.8541},
+		"Fethiye, Muğla":         {Latitude: 36.6217, Longitude: 29.1164},
+		"Kabak Koyu Kamp, Muğla": {Latitude: 36.4611, Longitude: 29.1258},
+	}
+	tests := []struct {
+		name    string
+		loc     models.Location
+		want    models.Coordinates
+		correct bool
+	}{
+		{"close enough", models.Location{Name: "Kaş Camping", Address: "Kaş, Antalya", Latitude: 36.2100, Longitude: 29.6400}, models.Coordinates{Latitude: 36.2100, Longitude: 29.6400}, false},
+		{"far from name", models.Location{Name: "Kaş Camping", Address: "Kaş, Antalya", Latitude: 41.01, Longitude: 28.98}, models.Coordinates{Latitude: 36.2012, Longitude: 29.6312}, true},
+		{"missing coordinates", models.Location{Name: "Kabak Koyu Kamp", Address: "Faralya, Fethiye, Muğla"}, models.Coordinates{Latitude: 36.4611, Longitude: 29.1258}, true},
+		{"address within wider threshold", models.Location{Name: "Bilinmeyen Kamp", Address: "Olimpos, Kumluca", Latitude: 36.40, Longitude: 30.30}, models.Coordinates{Latitude: 36.40, Longitude: 30.30}, false},
+		{"not found", models.Location{Name: "Bilinmeyen Kamp", Address: "Bilinmeyen Köy", Latitude: 36.5, Longitude: 30.5}, models.Coordinates{Latitude: 36.5, Longitude: 30.5}, false},
+	}
+
+	corrector := NewGeoCorrector(geocoder)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			daily := models.DailyPlan{Day: 1, Location: tt.loc}
+			correction := corrector.CorrectDay(context.Background(), &daily)
+			if (correction != nil) != tt.correct {
+				t.Fatalf("correction = %+v, want correct=%v", correction, tt.correct)
+			}
+			got := models.Coordinates{Latitude: daily.Location.Latitude, Longitude: daily.Location.Longitude}
+			if got != tt.want {
+				t.Errorf("coordinates = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	plan := &models.TripPlan{}
+	corrector.LocateEndpoints(context.Background(), models.PromptBody{StartPosition: "Ankara", EndPosition: "Fethiye, Muğla"}, plan)
+	if plan.Trip.StartCoordinates == nil || plan.Trip.StartCoordinates.Latitude != 39.9208 || plan.Trip.EndCoordinates == nil {
+		t.Errorf("endpoints = %+v, %+v", plan.Trip.StartCoordinates, plan.Trip.EndCoordinates)
+	}
+}
+
+type candidateGeocoder struct {
+	results map[string][]utils.GeocodeResult
+	calls   map[string]int
+}
+
+func (c *candidateGeocoder) Geocode(ctx context.Context, query string) (*utils.GeocodeResult, error) {
+	results, err := c.GeocodeCandidates(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &results[0], nil
+}
+
+func (c *candidateGeocoder) GeocodeCandidates(ctx context.Context, query string) ([]utils.GeocodeResult, error) {
+	c.calls[query]++
+	if results, ok := c.results[query]; ok {
+		return results, nil
+	}
+	return nil, utils.ErrNoGeocodeResult
+}
+
+func TestGeoCorrectorPrefersCampsitesAndMemoizes(t *testing.T) {
+	geocoder := &candidateGeocoder{calls: map[string]int{}, results: map[string][]utils.GeocodeResult{
+		// Aynı isimli köy önce gelir, kamp alanı ikinci sırada
+		"Çıralı Kamp, Antalya": {
+			{Latitude: 40.10, Longitude: 30.10, Class: "place", Type: "village"},
+			{Latitude: 36.4120, Longitude: 30.4790, Class: "tourism", Type: "camp_site"},
+		},
+		"Adrasan Kamp, Antalya": {
+			{Latitude: 39.00, Longitude: 31.00, Class: "highway", Type: "bus_stop"},
+			{Latitude: 36.3000, Longitude: 30.4500, Class: "tourism", Type: "guest_house"},
+		},
+		"Olimpos Kamp, Antalya": {{Latitude: 36.3958, Longitude: 30.4727, Class: "tourism", Type: "camp_site"}},
+	}}
+	corrector := NewGeoCorrector(geocoder)
+	newPlan := func(third string) *models.TripPlan {
+		return &models.TripPlan{DailyPlan: []models.DailyPlan{
+			{Day: 1, Location: models.Location{Name: "Çıralı Kamp", Address: "Çıralı, Kemer, Antalya"}},
+			{Day: 2, Location: models.Location{Name: "Adrasan Kamp", Address: "Adrasan, Kumluca, Antalya"}},
+			{Day: 3, Location: models.Location{Name: third, Address: "Kumluca, Antalya"}},
+		}}
+	}
+
+	memo := GeocodeMemo{}
+	plan := newPlan("Bilinmeyen Kamp")
+	corrector.Correct(context.Background(), plan, memo)
+	if loc := plan.DailyPlan[0].Location; loc.Latitude != 36.4120 {
+		t.Errorf("day 1 = %+v, want camp_site candidate", loc)
+	}
+	if loc := plan.DailyPlan[1].Location; loc.Latitude != 36.3000 {
+		t.Errorf("day 2 = %+v, want tourism candidate", loc)
+	}
+
+	// Sonraki turda sadece ismi değişen gün geocode edilir
+	plan = newPlan("Olimpos Kamp")
+	corrector.Correct(context.Background(), plan, memo)
+	for query, calls := range geocoder.calls {
+		if calls != 1 {
+			t.Errorf("%q geocoded %d times, want 1", query, calls)
+		}
+	}
+	if geocoder.calls["Olimpos Kamp, Antalya"] != 1 || plan.DailyPlan[2].Location.Latitude != 36.3958 {
+		t.Errorf("changed day not geocoded: %+v", plan.DailyPlan[2].Location)
+	}
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Link ihlal kodları
+const (
+	ViolationDeadLink     = "dead_link"
+	ViolationLinkMismatch = "link_title_mismatch"
+)
+
+const (
+	LINK_CHECK_TIMEOUT     = 5 * time.Second
+	LINK_CHECK_CONCURRENCY = 4
+	// Aynı URL tekrar kontrol edilmeden önce sonucun geçerli kaldığı süre
+	LINK_CHECK_CACHE_TTL = time.Hour
+	// Başlık aranırken okunacak en fazla gövde
+	MAX_TITLE_BYTES = 64 << 10
+	// Takip edilecek en fazla yönlendirme
+	MAX_LINK_REDIRECTS = 5
+)
+
+// Location.SiteStatus değerleri
+const (
+	SiteStatusOK       = "ok"
+	SiteStatusDead     = "dead"
+	SiteStatusMismatch = "title_mismatch"
+	// Ölü link planından silindi
+	SiteStatusDropped = "dropped"
+)
+
+// LinkChecker plandaki site_url'leri HEAD (olmazsa GET) ile kontrol eder.
+// CheckTitle açıksa sayfa başlığının veya alan adının kamp alanı adını
+// içerdiği de doğrulanır. DropDead açıksa ölü linkler plandan silinir,
+// kapalıysa ihlal olarak işaretlenir.
+type LinkChecker struct {
+	HTTPClient  *http.Client
+	Timeout     time.Duration
+	Concurrency int
+	CheckTitle  bool
+	DropDead    bool
+	UserAgent   string
+
+	mu    sync.Mutex
+	cache map[string]linkResult
+}
+
+type linkResult struct {
+	status  string
+	title   string
+	checked time.Time
+}
+
+// URL'ler modelden (dolayısıyla web araması sonuçlarından) geldiği için varsayılan
+// istemci yalnızca genel IP adreslerine bağlanır; yönlendirmeler de aynı dialer'dan geçer.
+func NewLinkChecker() *LinkChecker {
+	return &LinkChecker{
+		HTTPClient:  newPublicHTTPClient(),
+		Timeout:     LINK_CHECK_TIMEOUT,
+		Concurrency: LINK_CHECK_CONCURRENCY,
+		CheckTitle:  true,
+		UserAgent:   "ai-routes-service link checker",
+		cache:       map[string]linkResult{},
+	}
+}
+
+// Check tüm konakların linklerini eşzamanlı kontrol eder ve Location.SiteStatus'u doldurur
+func (c *LinkChecker) Check(ctx context.Context, plan *models.TripPlan) {
+	workers := c.Concurrency
+	if workers <= 0 {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i := range plan.DailyPlan {
+		loc := &plan.DailyPlan[i].Location
+		if strings.TrimSpace(loc.SiteURL) == "" {
+			if loc.SiteStatus != SiteStatusDropped {
+				loc.SiteStatus = ""
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(day int) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			status := c.checkLocation(ctx, loc.SiteURL, loc.Name)
+			if status == SiteStatusDead && c.DropDead {
+				log.Printf("🔗 Day %d: ölü link silindi: %s", day, loc.SiteURL)
+				loc.SiteURL, status = "", SiteStatusDropped
+			}
+			loc.SiteStatus = status
+		}(plan.DailyPlan[i].Day)
+	}
+	wg.Wait()
+}
+
+// Validate Check sonuçlarından ihlal listesi üretir
+func (c *LinkChecker) Validate(plan *models.TripPlan) []models.PlanViolation {
+	violations := []models.PlanViolation{}
+	for _, daily := range plan.DailyPlan {
+		loc := daily.Location
+		switch loc.SiteStatus {
+		case SiteStatusDead:
+			violations = append(violations, models.PlanViolation{Code: ViolationDeadLink, Day: daily.Day,
+				Message: fmt.Sprintf("%d. gün %s web sitesine ulaşılamıyor: %s", daily.Day, loc.Name, loc.SiteURL)})
+		case SiteStatusMismatch:
+			violations = append(violations, models.PlanViolation{Code: ViolationLinkMismatch, Day: daily.Day,
+				Message: fmt.Sprintf("%d. gün %s web sitesi bu kamp alanına ait görünmüyor: %s", daily.Day, loc.Name, loc.SiteURL)})
+		}
+	}
+	return violations
+}
+
+func (c *LinkChecker) checkLocation(ctx context.Context, rawURL, name string) string {
+	result, ok := c.cached(rawURL)
+	if !ok {
+		result = c.fetch(ctx, rawURL)
+		if ctx.Err() != nil {
+			// İptal edilen istek linkin ölü olduğunu göstermez
+			return ""
+		}
+		c.store(rawURL, result)
+	}
+	if result.status == SiteStatusOK && c.CheckTitle && !mentionsCampsite(rawURL, result.title, name) {
+		return SiteStatusMismatch
+	}
+	return result.status
+}
+
+func (c *LinkChecker) cached(rawURL string) (linkResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.cache[rawURL]
+	if !ok || time.Since(result.checked) > LINK_CHECK_CACHE_TTL {
+		return linkResult{}, false
+	}
+	return result, true
+}
+
+func (c *LinkChecker) store(rawURL string, result linkResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cache == nil {
+		c.cache = map[string]linkResult{}
+	}
+	c.cache[rawURL] = result
+}
+
+// fetch başlık gerekmiyorsa önce HEAD dener; HEAD desteklemeyen sunucular için GET'e düşer
+func (c *LinkChecker) fetch(ctx context.Context, rawURL string) linkResult {
+	result := linkResult{status: SiteStatusDead, checked: time.Now()}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return result
+	}
+
+	if !c.CheckTitle {
+		if code, _, err := c.request(ctx, http.MethodHead, rawURL); err == nil && code < 400 {
+			result.status = SiteStatusOK
+			return result
+		}
+	}
+	code, title, err := c.request(ctx, http.MethodGet, rawURL)
+	if err != nil || code >= 400 {
+		return result
+	}
+	result.status, result.title = SiteStatusOK, title
+	return result
+}
+
+func (c *LinkChecker) request(ctx context.Context, method, rawURL string) (int, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	if method != http.MethodGet || resp.StatusCode >= 400 {
+		return resp.StatusCode, "", nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, MAX_TITLE_BYTES))
+	return resp.StatusCode, pageTitle(body), nil
+}
+
+var errNonPublicAddress = errors.New("non-public address")
+
+// newPublicHTTPClient DNS çözümlemesinden sonra bağlanılan adresi kontrol eder;
+// loopback, özel ağ, link-local (169.254.169.254 gibi) ve benzeri adresleri reddeder.
+// Proxy kullanılmaz, aksi halde kontrol hedef yerine proxy adresine uygulanırdı.
+func newPublicHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: LINK_CHECK_TIMEOUT,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("%w: %s", errNonPublicAddress, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= MAX_LINK_REDIRECTS {
+				return fmt.Errorf("stopped after %d redirects", MAX_LINK_REDIRECTS)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("unsupported redirect scheme: %s", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// 100.64.0.0/10 (CGNAT) net.IP.IsPrivate kapsamında değil
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+var titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+func pageTitle(body []byte) string {
+	match := titlePattern.FindSubmatch(body)
+	if match == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(string(match[1]))), " ")
+}
+
+// Kamp alanı adlarında ayırt edici olmayan kelimeler
+var genericNameWords = map[string]bool{
+	"kamp": true, "kamping": true, "camp": true, "camping": true, "campsite": true, "alani": true,
+	"tesisleri": true, "tesisi": true, "park": true, "site": true, "karavan": true, "caravan": true,
+}
+
+// mentionsCampsite başlığın veya alan adının isimdeki ayırt edici bir kelimeyi
+// içerip içermediğini söyler. Başlık yoksa veya isimde ayırt edici kelime yoksa
+// karar verilemez ve link kabul edilir.
+func mentionsCampsite(rawURL, title, name string) bool {
+	if title == "" {
+		return true
+	}
+	fold := func(s string) string { return asciiFold.Replace(strings.ToLower(strings.ReplaceAll(s, "İ", "i"))) }
+	haystack := fold(title)
+	if u, err := url.Parse(rawURL); err == nil {
+		haystack += " " + fold(u.Hostname())
+	}
+
+	distinctive := false
+	for _, word := range strings.FieldsFunc(fold(name), func(r rune) bool { return !('a' <= r && r <= 'z') && !('0' <= r && r <= '9') }) {
+		if len(word) < 3 || genericNameWords[word] {
+			continue
+		}
+		distinctive = true
+		if strings.Contains(haystack, word) {
+			return true
+		}
+	}
+	return !distinctive
+}
+
+// checkLinks yapılandırılmışsa plandaki linkleri kontrol eder
+func (s *AIService) checkLinks(ctx context.Context, plan *models.TripPlan) {
+	if s.Links != nil {
+		s.Links.Check(ctx, plan)
+	}
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLinkChecker(t *testing.T) {
+	var inFlight, maxInFlight, requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			max := maxInFlight.Load()
+			if n <= max || maxInFlight.CompareAndSwap(max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		switch r.URL.Path {
+		case "/kas":
+			w.Write([]byte("<html><head><title>Kaş Camping &amp; Pansiyon</title></head></html>"))
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Write([]byte("<title>Olympos Orange</title>"))
+		case "/parked":
+			w.Write([]byte("<title>This domain is for sale</title>"))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	newPlan := func() *models.TripPlan {
+		plan := validPlan()
+		plan.DailyPlan[0].Location.SiteURL = server.URL + "/kas"
+		plan.DailyPlan[0].Location.Name = "Kaş Camping"
+		plan.DailyPlan[1].Location.SiteURL = server.URL + "/parked"
+		plan.DailyPlan[2].Location.SiteURL = server.URL + "/missing"
+		plan.DailyPlan = append(plan.DailyPlan,
+			models.DailyPlan{Day: 4, Location: models.Location{Name: "Olympos Orange Camp", SiteURL: server.URL + "/no-head"}},
+			models.DailyPlan{Day: 5, Location: models.Location{Name: "Yavaş Kamp", SiteURL: server.URL + "/slow"}},
+			models.DailyPlan{Day: 6, Location: models.Location{Name: "Bozuk Kamp", SiteURL: "ftp://example.com"}},
+			models.DailyPlan{Day: 7, Location: models.Location{Name: "Linksiz Kamp"}},
+		)
+		return plan
+	}
+
+	checker := NewLinkChecker()
+	checker.HTTPClient = server.Client()
+	checker.Timeout = 50 * time.Millisecond
+	checker.Concurrency = 2
+
+	plan := newPlan()
+	checker.Check(context.Background(), plan)
+	want := []string{SiteStatusOK, SiteStatusMismatch, SiteStatusDead, SiteStatusOK, SiteStatusDead, SiteStatusDead, ""}
+	for i, status := range want {
+		if got := plan.DailyPlan[i].Location.SiteStatus; got != status {
+			t.Errorf("day %d status = %q, want %q", i+1, got, status)
+		}
+	}
+	if max := maxInFlight.Load(); max > 2 {
+		t.Errorf("max concurrent requests = %d, want <= 2", max)
+	}
+	violations := checker.Validate(plan)
+	if len(violations) != 4 || violations[0].Code != ViolationLinkMismatch || violations[1].Code != ViolationDeadLink {
+		t.Errorf("violations = %+v", violations)
+	}
+
+	// Sonuçlar önbellekten gelir; drop modunda ölü linkler silinir
+	before := requests.Load()
+	checker.DropDead = true
+	plan = newPlan()
+	checker.Check(context.Background(), plan)
+	if requests.Load() != before {
+		t.Errorf("cached links requested again: %d new requests", requests.Load()-before)
+	}
+	if loc := plan.DailyPlan[2].Location; loc.SiteURL != "" || loc.SiteStatus != SiteStatusDropped {
+		t.Errorf("dead link not dropped: %+v", loc)
+	}
+	if violations := checker.Validate(plan); len(violations) != 1 || violations[0].Code != ViolationLinkMismatch {
+		t.Errorf("violations after drop = %+v", violations)
+	}
+}
+
+func TestLinkCheckerRefusesNonPublicAddresses(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"8.8.8.8": true, "2a00:1450:4017:80b::200e": true,
+		"127.0.0.1": false, "::1": false, "10.0.0.5": false, "192.168.1.1": false, "172.16.0.1": false,
+		"169.254.169.254": false, "fe80::1": false, "100.64.0.1": false, "0.0.0.0": false, "::ffff:127.0.0.1": false,
+	} {
+		if got := isPublicIP(net.ParseIP(addr)); got != want {
+			t.Errorf("isPublicIP(%s) = %v, want %v", addr, got, want)
+		}
+	}
+
+	// Varsayılan istemci loopback'teki sunucuya bağlanmaz
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte("<title>Metadata</title>"))
+	}))
+	defer server.Close()
+	plan := &models.TripPlan{DailyPlan: []models.DailyPlan{{Day: 1, Location: models.Location{Name: "Kamp", SiteURL: server.URL + "/latest/meta-data"}}}}
+	NewLinkChecker().Check(context.Background(), plan)
+	if status := plan.DailyPlan[0].Location.SiteStatus; status != SiteStatusDead || requests.Load() != 0 {
+		t.Errorf("loopback link status = %q after %d requests, want dead and none", status, requests.Load())
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if _, err := newPublicHTTPClient().Do(req); !errors.Is(err, errNonPublicAddress) {
+		t.Errorf("loopback request error = %v, want errNonPublicAddress", err)
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+
+	"google.golang.org/genai"
+)
+
+// Provider AIService'in konuştuğu dil modeli arka ucunu soyutlar.
+// İstek/yanıt tipleri olarak genai tipleri kullanılır; Gemini dışındaki
+// sağlayıcılar bu tipleri kendi API formatlarına çevirir.
+type Provider interface {
+	// ModelName sağlayıcının kullandığı model adını döndürür
+	ModelName() string
+	// Generate tek bir içerik üretme çağrısı yapar
+	Generate(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error)
+	// GenerateWithTools verilen tool'ları config'e ekleyerek içerik üretir
+	GenerateWithTools(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, tools []*genai.Tool) (*genai.GenerateContentResponse, error)
+	// CountTokens içeriklerin token sayısını döndürür
+	CountTokens(ctx context.Context, contents []*genai.Content) (int32, error)
+}
+
+// StreamingProvider yanıtı parça parça üretebilen sağlayıcılar için isteğe bağlı arayüz.
+// onText her parçadan sonra o ana kadar gelen toplam metinle çağrılır; dönen
+// yanıt Generate'in döndüreceği birleşik yanıtla aynı şekildedir.
+type StreamingProvider interface {
+	GenerateStream(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, onText func(text string)) (*genai.GenerateContentResponse, error)
+}
+
+// Config'i kopyalayıp tool listesini ekler, çağıranın config'i değişmez
+func withTools(config *genai.GenerateContentConfig, tools []*genai.Tool) *genai.GenerateContentConfig {
+	cfg := &genai.GenerateContentConfig{}
+	if config != nil {
+		copied := *config
+		cfg = &copied
+	}
+	cfg.Tools = tools
+	return cfg
+}
+
+// Token sayma desteği olmayan sağlayıcılar için kaba tahmin (~4 karakter = 1 token)
+func estimateTokens(contents []*genai.Content) int32 {
+	chars := 0
+	for _, content := range contents {
+		if content == nil {
+			continue
+		}
+		for _, part := range content.Parts {
+			chars += len(part.Text)
+		}
+	}
+	return int32((chars + 3) / 4)
+}
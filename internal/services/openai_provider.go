@@ -0,0 +1,311 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// OpenAIProvider OpenAI uyumlu /chat/completions API'si sunan sunucularla
+// (OpenAI, vLLM, Ollama, LM Studio vb.) konuşan Provider
+type OpenAIProvider struct {
+	BaseURL    string
+	APIKey     string
+	Model      string
+	HTTPClient *http.Client
+}
+
+func NewOpenAIProvider(baseURL string, apiKey string, model string) *OpenAIProvider {
+	return &OpenAIProvider{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		APIKey:     apiKey,
+		Model:      model,
+		HTTPClient: &http.Client{Timeout: REQUEST_TIMEOUT},
+	}
+}
+
+type openAIChatRequest struct {
+	Model          string                `json:"model"`
+	Messages       []openAIMessage       `json:"messages"`
+	Tools          []openAITool          `json:"tools,omitempty"`
+	MaxTokens      int32                 `json:"max_tokens,omitempty"`
+	Temperature    *float32              `json:"temperature,omitempty"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIResponseFormat struct {
+	Type       string            `json:"type"`
+	JSONSchema *openAIJSONSchema `json:"json_schema,omitempty"`
+}
+
+type openAIJSONSchema struct {
+	Name   string         `json:"name"`
+	Schema map[string]any `json:"schema"`
+}
+
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    string           `json:"content"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+type openAITool struct {
+	Type     string             `json:"type"`
+	Function openAIFunctionDecl `json:"function"`
+}
+
+type openAIFunctionDecl struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+type openAIToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int32 `json:"prompt_tokens"`
+		CompletionTokens int32 `json:"completion_tokens"`
+		TotalTokens      int32 `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+func (p *OpenAIProvider) ModelName() string {
+	return p.Model
+}
+
+func (p *OpenAIProvider) Generate(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	reqBody := openAIChatRequest{
+		Model:    p.Model,
+		Messages: toOpenAIMessages(contents, config),
+	}
+	if config != nil {
+		reqBody.MaxTokens = config.MaxOutputTokens
+		reqBody.Temperature = config.Temperature
+		reqBody.ResponseFormat = toOpenAIResponseFormat(config)
+		for _, tool := range config.Tools {
+			for _, decl := range tool.FunctionDeclarations {
+				reqBody.Tools = append(reqBody.Tools, openAITool{
+					Type: "function",
+					Function: openAIFunctionDecl{
+						Name:        decl.Name,
+						Description: decl.Description,
+						Parameters:  toJSONSchema(decl.Parameters),
+					},
+				})
+			}
+		}
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("openai request encode failed: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.BaseURL+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openai request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("openai response read failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openai API error: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var chatResp openAIChatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return nil, fmt.Errorf("openai response decode failed: %w", err)
+	}
+
+	return fromOpenAIResponse(&chatResp), nil
+}
+
+func (p *OpenAIProvider) GenerateWithTools(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, tools []*genai.Tool) (*genai.GenerateContentResponse, error) {
+	return p.Generate(ctx, contents, withTools(config, tools))
+}
+
+// OpenAI uyumlu API'lerde standart bir token sayma uç noktası yok
+func (p *OpenAIProvider) CountTokens(ctx context.Context, contents []*genai.Content) (int32, error) {
+	return estimateTokens(contents), nil
+}
+
+// genai içeriklerini chat mesajlarına çevirir
+func toOpenAIMessages(contents []*genai.Content, config *genai.GenerateContentConfig) []openAIMessage {
+	var messages []openAIMessage
+
+	if config != nil && config.SystemInstruction != nil {
+		if system := contentText(config.SystemInstruction); system != "" {
+			messages = append(messages, openAIMessage{Role: "system", Content: system})
+		}
+	}
+
+	for _, content := range contents {
+		if content == nil {
+			continue
+		}
+
+		if content.Role == genai.RoleModel {
+			msg := openAIMessage{Role: "assistant", Content: contentText(content)}
+			for _, part := range content.Parts {
+				if part.FunctionCall == nil {
+					continue
+				}
+				args, _ := json.Marshal(part.FunctionCall.Args)
+				call := openAIToolCall{ID: toolCallID(part.FunctionCall.ID, part.FunctionCall.Name), Type: "function"}
+				call.Function.Name = part.FunctionCall.Name
+				call.Function.Arguments = string(args)
+				msg.ToolCalls = append(msg.ToolCalls, call)
+			}
+			messages = append(messages, msg)
+			continue
+		}
+
+		// Function response'lar "tool" mesajı olarak gider, kalan metin user mesajı olur
+		for _, part := range content.Parts {
+			if fr := part.FunctionResponse; fr != nil {
+				result, _ := json.Marshal(fr.Response)
+				messages = append(messages, openAIMessage{
+					Role:       "tool",
+					Content:    string(result),
+					ToolCallID: toolCallID(fr.ID, fr.Name),
+				})
+			}
+		}
+		if text := contentText(content); text != "" {
+			messages = append(messages, openAIMessage{Role: "user", Content: text})
+		}
+	}
+
+	return messages
+}
+
+func fromOpenAIResponse(chatResp *openAIChatResponse) *genai.GenerateContentResponse {
+	result := &genai.GenerateContentResponse{
+		UsageMetadata: &genai.GenerateContentResponseUsageMetadata{
+			PromptTokenCount:     chatResp.Usage.PromptTokens,
+			CandidatesTokenCount: chatResp.Usage.CompletionTokens,
+			TotalTokenCount:      chatResp.Usage.TotalTokens,
+		},
+	}
+	if len(chatResp.Choices) == 0 {
+		return result
+	}
+
+	choice := chatResp.Choices[0]
+	content := &genai.Content{Role: genai.RoleModel}
+	if choice.Message.Content != "" {
+		content.Parts = append(content.Parts, genai.NewPartFromText(choice.Message.Content))
+	}
+	for _, call := range choice.Message.ToolCalls {
+		args := map[string]any{}
+		if call.Function.Arguments != "" {
+			_ = json.Unmarshal([]byte(call.Function.Arguments), &args)
+		}
+		content.Parts = append(content.Parts, &genai.Part{
+			FunctionCall: &genai.FunctionCall{ID: call.ID, Name: call.Function.Name, Args: args},
+		})
+	}
+
+	finishReason := genai.FinishReasonStop
+	if choice.FinishReason == "length" {
+		finishReason = genai.FinishReasonMaxTokens
+	}
+
+	result.Candidates = []*genai.Candidate{{Content: content, FinishReason: finishReason}}
+	return result
+}
+
+// ResponseMIMEType/ResponseSchema'yı response_format'a çevirir
+func toOpenAIResponseFormat(config *genai.GenerateContentConfig) *openAIResponseFormat {
+	if config.ResponseMIMEType != "application/json" {
+		return nil
+	}
+	if config.ResponseSchema == nil {
+		return &openAIResponseFormat{Type: "json_object"}
+	}
+	return &openAIResponseFormat{
+		Type:       "json_schema",
+		JSONSchema: &openAIJSONSchema{Name: "response", Schema: toJSONSchema(config.ResponseSchema)},
+	}
+}
+
+// genai.Schema'yı JSON Schema map'ine çevirir (genai tipleri büyük harfli)
+func toJSONSchema(schema *genai.Schema) map[string]any {
+	if schema == nil {
+		return nil
+	}
+
+	out := map[string]any{}
+	if schema.Type != genai.TypeUnspecified {
+		out["type"] = strings.ToLower(string(schema.Type))
+	}
+	if schema.Description != "" {
+		out["description"] = schema.Description
+	}
+	if len(schema.Enum) > 0 {
+		out["enum"] = schema.Enum
+	}
+	if len(schema.Properties) > 0 {
+		props := map[string]any{}
+		for name, prop := range schema.Properties {
+			props[name] = toJSONSchema(prop)
+		}
+		out["properties"] = props
+	}
+	if len(schema.Required) > 0 {
+		out["required"] = schema.Required
+	}
+	if schema.Items != nil {
+		out["items"] = toJSONSchema(schema.Items)
+	}
+	return out
+}
+
+func contentText(content *genai.Content) string {
+	var texts []string
+	for _, part := range content.Parts {
+		if part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// Gemini function call'larında ID çoğu zaman boş geliyor, isimle eşleştiriyoruz
+func toolCallID(id string, name string) string {
+	if id != "" {
+		return id
+	}
+	return "call_" + name
+}
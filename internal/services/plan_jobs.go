@@ -0,0 +1,215 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/storage"
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	JOB_WORKERS    = 2
+	JOB_QUEUE_SIZE = 100
+	// Biten job'lar bu süre sonunda bellekten silinir
+	JOB_RETENTION = 1 * time.Hour
+)
+
+var (
+	ErrJobQueueFull = errors.New("plan job queue is full")
+	ErrJobNotFound  = errors.New("plan job not found")
+)
+
+// PlanGenerator job'ları çalıştıran servis (AIService bu arayüzü sağlar)
+type PlanGenerator interface {
+	GenerateTripPlan(ctx context.Context, prompt models.PromptBody) (*models.PlanResult, error)
+}
+
+// JobQueue plan üretimini sabit sayıda worker ile arka planda çalıştırır.
+// Job'lar bellekte tutulur; servis yeniden başlarsa kaybolur.
+type JobQueue struct {
+	Generator PlanGenerator
+	Retention time.Duration
+
+	mu     sync.Mutex
+	jobs   map[string]*models.PlanJob
+	queue  chan string
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewJobQueue worker'ları başlatır. Job'lar istek context'inden bağımsız
+// çalışır ve sadece Shutdown ile iptal edilir.
+func NewJobQueue(generator PlanGenerator, workers int, size int) *JobQueue {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &JobQueue{
+		Generator: generator,
+		Retention: JOB_RETENTION,
+		jobs:      map[string]*models.PlanJob{},
+		queue:     make(chan string, size),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	for i := 0; i < workers; i++ {
+		q.wg.Add(1)
+		go q.worker()
+	}
+	log.Printf("🧵 Job queue: %d worker, kuyruk kapasitesi %d", workers, size)
+	return q
+}
+
+// Submit planı kuyruğa ekler; kuyruk doluysa ErrJobQueueFull döner
+func (q *JobQueue) Submit(prompt models.PromptBody) (models.PlanJob, error) {
+	// Geçersiz tarihler job oluşmadan reddedilir
+	prompt, err := NormalizePrompt(prompt)
+	if err != nil {
+		return models.PlanJob{}, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pruneLocked()
+
+	job := &models.PlanJob{
+		ID:        uuid.NewString(),
+		Status:    models.JobStatusQueued,
+		Prompt:    prompt,
+		CreatedAt: time.Now(),
+	}
+
+	select {
+	case q.queue <- job.ID:
+	default:
+		return models.PlanJob{}, ErrJobQueueFull
+	}
+	q.jobs[job.ID] = job
+	log.Printf("📨 Job kuyruğa alındı: %s", job.ID)
+	return *job, nil
+}
+
+// Get job'un anlık kopyasını döndürür
+func (q *JobQueue) Get(id string) (models.PlanJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok {
+		return models.PlanJob{}, ErrJobNotFound
+	}
+	return *job, nil
+}
+
+// Lookup job kuyrukta yoksa (süresi dolmuş, yeniden başlatmada kaybolmuş veya
+// revizyonla oluşmuş) kayıtlı planı aynı job şekliyle döndürür. store nil olabilir.
+func (q *JobQueue) Lookup(ctx context.Context, store storage.PlanStore, id string) (models.PlanJob, error) {
+	job, err := q.Get(id)
+	if !errors.Is(err, ErrJobNotFound) || store == nil {
+		return job, err
+	}
+	record, err := store.GetPlan(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrPlanNotFound) {
+			return models.PlanJob{}, ErrJobNotFound
+		}
+		return models.PlanJob{}, err
+	}
+	return jobFromRecord(record), nil
+}
+
+// Kayıtlı plan bitmiş bir job'dur: hata varsa failed, yoksa done
+func jobFromRecord(record *models.PlanRecord) models.PlanJob {
+	started, finished := record.StartedAt, record.FinishedAt
+	job := models.PlanJob{
+		ID:         record.ID,
+		Status:     models.JobStatusDone,
+		Prompt:     record.Prompt,
+		Result:     record.Result,
+		CreatedAt:  started,
+		StartedAt:  &started,
+		FinishedAt: &finished,
+	}
+	if record.Error != "" {
+		job.Status = models.JobStatusFailed
+		job.Error = record.Error
+	}
+	return job
+}
+
+// Shutdown çalışan job'ları iptal eder ve worker'ların bitmesini bekler
+func (q *JobQueue) Shutdown() {
+	q.cancel()
+	q.wg.Wait()
+}
+
+func (q *JobQueue) worker() {
+	defer q.wg.Done()
+	for {
+		select {
+		case <-q.ctx.Done():
+			return
+		case id := <-q.queue:
+			q.run(id)
+		}
+	}
+}
+
+func (q *JobQueue) run(id string) {
+	q.mu.Lock()
+	job, ok := q.jobs[id]
+	if !ok {
+		q.mu.Unlock()
+		return
+	}
+	started := time.Now()
+	job.Status = models.JobStatusRunning
+	job.StartedAt = &started
+	prompt := job.Prompt
+	q.mu.Unlock()
+
+	log.Printf("⚙️ Job çalışıyor: %s", id)
+	// Kaydedilen plan job ile aynı ID'yi alır, /plans/{id} altındaki diğer işlemler bu ID ile çalışır
+	result, err := q.Generator.GenerateTripPlan(withPlanID(q.ctx, id), prompt)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	finished := time.Now()
+	job.FinishedAt = &finished
+	if err != nil {
+		log.Printf("❌ Job başarısız: %s: %v", id, err)
+		job.Status = models.JobStatusFailed
+		job.Error = err.Error()
+		return
+	}
+	log.Printf("✅ Job tamamlandı: %s (%s)", id, finished.Sub(started).Round(time.Millisecond))
+	job.Status = models.JobStatusDone
+	job.Result = result
+}
+
+// Retention süresini aşmış bitmiş job'ları siler
+func (q *JobQueue) pruneLocked() {
+	cutoff := time.Now().Add(-q.Retention)
+	for id, job := range q.jobs {
+		if job.FinishedAt != nil && job.FinishedAt.Before(cutoff) {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+type planIDKey struct{}
+
+func withPlanID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, planIDKey{}, id)
+}
+
+// Context'te atanmış plan ID'si yoksa yeni bir ID üretir
+func planIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(planIDKey{}).(string); ok && id != "" {
+		return id
+	}
+	return uuid.NewString()
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Her çağrıda release kanalından sonuç bekleyen generator
+type blockingGenerator struct {
+	started chan struct{}
+	release chan error
+}
+
+func (g *blockingGenerator) GenerateTripPlan(ctx context.Context, prompt models.PromptBody) (*models.PlanResult, error) {
+	g.started <- struct{}{}
+	select {
+	case err := <-g.release:
+		if err != nil {
+			return nil, err
+		}
+		return &models.PlanResult{Plan: &models.TripPlan{}, Status: models.PlanStatusOK}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func waitForJob(t *testing.T, q *JobQueue, id string, want string) models.PlanJob {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		job, err := q.Get(id)
+		if err != nil {
+			t.Fatalf("Get(%s): %v", id, err)
+		}
+		if job.Status == want {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %s status = %s, want %s", id, job.Status, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestJobQueueLifecycle(t *testing.T) {
+	gen := &blockingGenerator{started: make(chan struct{}, 2), release: make(chan error)}
+	q := NewJobQueue(gen, 1, 1)
+	defer q.Shutdown()
+
+	first, err := q.Submit(testPrompt())
+	if err != nil || first.Status != models.JobStatusQueued {
+		t.Fatalf("Submit = %+v, %v", first, err)
+	}
+	<-gen.started
+	waitForJob(t, q, first.ID, models.JobStatusRunning)
+
+	// Tek worker meşgul, kuyrukta bir yer var
+	second, err := q.Submit(testPrompt())
+	if err != nil {
+		t.Fatalf("second Submit: %v", err)
+	}
+	if _, err := q.Submit(testPrompt()); !errors.Is(err, ErrJobQueueFull) {
+		t.Errorf("third Submit err = %v, want ErrJobQueueFull", err)
+	}
+
+	gen.release <- nil
+	done := waitForJob(t, q, first.ID, models.JobStatusDone)
+	if done.Result == nil || done.StartedAt == nil || done.FinishedAt == nil {
+		t.Errorf("done job = %+v", done)
+	}
+
+	<-gen.started
+	gen.release <- errors.New("boom")
+	failed := waitForJob(t, q, second.ID, models.JobStatusFailed)
+	if failed.Error != "boom" || failed.Result != nil {
+		t.Errorf("failed job = %+v", failed)
+	}
+
+	if _, err := q.Get("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("Get(missing) err = %v, want ErrJobNotFound", err)
+	}
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+var (
+	ErrStorageDisabled  = errors.New("plan storage is disabled")
+	ErrPlanNotRevisable = errors.New("plan has no result to revise")
+	ErrEmptyInstruction = errors.New("revision instruction is empty")
+	ErrDayOutOfRange    = errors.New("day is not part of the plan")
+)
+
+// RevisePlan kayıtlı planı kullanıcının serbest metin talimatıyla birlikte
+// modele gönderir, yeni sürümü kaydeder ve değişen günleri döndürür.
+func (s *AIService) RevisePlan(ctx context.Context, planID string, instruction string) (*models.PlanRevision, error) {
+	instruction = strings.TrimSpace(instruction)
+	if instruction == "" {
+		return nil, ErrEmptyInstruction
+	}
+
+	started := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, REQUEST_TIMEOUT)
+	defer cancel()
+
+	parent, err := s.loadRevisable(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("✏️ Revising plan %s (v%d): %s", parent.ID, parent.Version, instruction)
+
+	current, err := json.Marshal(parent.Result.Plan)
+	if err != nil {
+		return nil, err
+	}
+	prompt := parent.Prompt
+	userPrompt := fmt.Sprintf(`KAMP ROTASI BİLGİLERİ:
+ID: %s
+İsim: %s
+Açıklama: %s
+Başlangıç: %s → Bitiş: %s
+Tarih: %s - %s%s
+
+MEVCUT PLAN:
+%s
+
+DEĞİŞİKLİK İSTEĞİ:
+%s
+
+Mevcut planı bu isteğe göre güncelle. İstekle ilgisi olmayan günleri aynen koru.
+Tarih aralığı değişiyorsa trip.start_date ve trip.end_date alanlarını güncelle; end_date son gecenin ertesi günüdür.
+Planın tamamını aynı JSON formatında döndür.`,
+		prompt.UserID, prompt.Name, prompt.Description,
+		prompt.StartPosition, prompt.EndPosition,
+		prompt.StartDate, prompt.EndDate, preferencesSection(prompt),
+		current, instruction)
+
+	config := planGenerationConfig()
+	contents := []*genai.Content{
+		genai.NewContentFromText(userPrompt, genai.RoleUser),
+	}
+
+	resp, err := s.Provider.Generate(ctx, contents, config)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		log.Printf("❌ Revision failed: %v", err)
+		return nil, fmt.Errorf("%w: %s", ErrPlanUnavailable, DegradedGenerationFailed)
+	}
+	if resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPlanUnavailable, DegradedEmptyResponse)
+	}
+	revised, err := s.parseTripPlan(resp.Text())
+	if err != nil {
+		log.Printf("⚠️ Revised plan parse failed: %v", err)
+		return nil, fmt.Errorf("%w: %s", ErrPlanUnavailable, DegradedInvalidResponse)
+	}
+
+	// Talimat tarihleri değiştirebilir ("bir gece daha ekle"), doğrulama yeni aralığa göre yapılır.
+	// Kullanıcı sırayı bilerek değiştirmiş olabilir, konak sırası optimize edilmez.
+	revisedPrompt := revisedPromptBody(prompt, revised)
+	result, err := s.repairPlan(withFixedOrder(ctx), revisedPrompt, revised, contents, resp.Candidates[0].Content, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveRevision(ctx, parent, instruction, revisedPrompt, result, started)
+}
+
+// Revize planı parent'ın bir sonraki sürümü olarak kaydeder ve farkı hesaplar
+func (s *AIService) saveRevision(ctx context.Context, parent *models.PlanRecord, instruction string, prompt models.PromptBody, result *models.PlanResult, started time.Time) (*models.PlanRevision, error) {
+	finished := time.Now()
+	record := &models.PlanRecord{
+		ID:          planIDFromContext(ctx),
+		UserID:      parent.UserID,
+		ParentID:    parent.ID,
+		Version:     parent.Version + 1,
+		Instruction: instruction,
+		Prompt:      prompt,
+		Result:      result,
+		Model:       s.Provider.ModelName(),
+		StartedAt:   started,
+		FinishedAt:  finished,
+		DurationMs:  finished.Sub(started).Milliseconds(),
+	}
+	if err := s.storeRecord(ctx, record); err != nil {
+		return nil, err
+	}
+
+	return &models.PlanRevision{
+		ParentID: parent.ID,
+		Version:  record.Version,
+		Result:   result,
+		Changes:  DiffPlans(parent.Result.Plan, result.Plan),
+	}, nil
+}
+
+// Revize edilecek kaydı yükler
+func (s *AIService) loadRevisable(ctx context.Context, planID string) (*models.PlanRecord, error) {
+	if s.Store == nil {
+		return nil, ErrStorageDisabled
+	}
+	parent, err := s.Store.GetPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.Result == nil || parent.Result.Plan == nil {
+		return nil, ErrPlanNotRevisable
+	}
+	return parent, nil
+}
+
+// Revize planın geçerli tarih aralığını isteğe yansıtır
+func revisedPromptBody(prompt models.PromptBody, plan *models.TripPlan) models.PromptBody {
+	revised := prompt
+	revised.StartDate, revised.EndDate = plan.Trip.StartDate, plan.Trip.EndDate
+	if normalized, err := NormalizePrompt(revised); err == nil {
+		return normalized
+	}
+	return prompt
+}
+
+// DiffPlans iki plan arasında gün numarasına göre eklenen, silinen ve
+// tarihi veya kamp alanı değişen günleri döndürür
+func DiffPlans(before, after *models.TripPlan) []models.DayChange {
+	changes := []models.DayChange{}
+	for i := 0; i < len(before.DailyPlan) || i < len(after.DailyPlan); i++ {
+		switch {
+		case i >= len(after.DailyPlan):
+			old := before.DailyPlan[i]
+			changes = append(changes, models.DayChange{Day: i + 1, Change: models.DayRemoved, Before: &old})
+		case i >= len(before.DailyPlan):
+			added := after.DailyPlan[i]
+			changes = append(changes, models.DayChange{Day: i + 1, Change: models.DayAdded, After: &added})
+		case !sameDay(before.DailyPlan[i], after.DailyPlan[i]):
+			old, updated := before.DailyPlan[i], after.DailyPlan[i]
+			changes = append(changes, models.DayChange{Day: i + 1, Change: models.DayChanged, Before: &old, After: &updated})
+		}
+	}
+	return changes
+}
+
+// Mesafe komşu günlerden hesaplandığı için karşılaştırmaya girmez
+func sameDay(a, b models.DailyPlan) bool {
+	a.DistanceKm, b.DistanceKm = 0, 0
+	return reflect.DeepEqual(a, b)
+}
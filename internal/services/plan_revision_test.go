@@ -0,0 +1,113 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"ai-routes-service/internal/storage"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// validPlan'ı "p-1" ID'siyle kaydedilmiş bir store döndürür
+func storeWithPlan(t *testing.T) *storage.SQLStore {
+	t.Helper()
+	store, err := storage.Open(storage.DriverSQLite, filepath.Join(t.TempDir(), "plans.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	original := validPlan()
+	original.Trip = models.Trip{StartDate: "2025-08-01", EndDate: "2025-08-04", TotalDays: 4}
+	parent := &models.PlanRecord{
+		ID: "p-1", UserID: "u-1", Version: 1, Prompt: testPrompt(), Model: testModel,
+		Result:    &models.PlanResult{Plan: original, Status: models.PlanStatusOK},
+		StartedAt: time.Now(), FinishedAt: time.Now(),
+	}
+	if err := store.SavePlan(context.Background(), parent); err != nil {
+		t.Fatalf("SavePlan: %v", err)
+	}
+	return store
+}
+
+func TestRevisePlanStoresNewVersionWithDiff(t *testing.T) {
+	store := storeWithPlan(t)
+
+	// Model 3. günü değiştirip bir gece ekliyor
+	revised := validPlan()
+	revised.Trip = models.Trip{StartDate: "2025-08-01", EndDate: "2025-08-05", TotalDays: 5}
+	revised.DailyPlan[2].Location = models.Location{Name: "Çıralı Sahil Kamp", Latitude: 36.41, Longitude: 30.47}
+	revised.DailyPlan = append(revised.DailyPlan, models.DailyPlan{Day: 4, Date: "2025-08-04",
+		Location: models.Location{Name: "Olympos Orange Camp", Latitude: 36.398765, Longitude: 30.471234}})
+	data, _ := json.Marshal(revised)
+
+	provider := NewFakeProvider(testModel, FakeTextResponse(string(data)))
+	service := &AIService{Provider: provider, Search: fixtureSearch(), Store: store, MaxRepairRounds: 1}
+
+	revision, err := service.RevisePlan(context.Background(), "p-1", "3. günü deniz kenarına al ve bir gece ekle")
+	if err != nil {
+		t.Fatalf("RevisePlan: %v", err)
+	}
+	if revision.ParentID != "p-1" || revision.Version != 2 || len(revision.Result.Violations) != 0 {
+		t.Errorf("revision = %+v, violations = %+v", revision, revision.Result.Violations)
+	}
+	if len(revision.Changes) != 2 ||
+		revision.Changes[0].Day != 3 || revision.Changes[0].Change != models.DayChanged ||
+		revision.Changes[1].Day != 4 || revision.Changes[1].Change != models.DayAdded {
+		t.Errorf("changes = %+v", revision.Changes)
+	}
+
+	stored, err := store.GetPlan(context.Background(), revision.Result.ID)
+	if err != nil {
+		t.Fatalf("GetPlan: %v", err)
+	}
+	if stored.ParentID != "p-1" || stored.Version != 2 || stored.Prompt.EndDate != "2025-08-05" || stored.Instruction == "" {
+		t.Errorf("stored revision = %+v", stored)
+	}
+
+	if _, err := service.RevisePlan(context.Background(), "missing", "x"); !errors.Is(err, storage.ErrPlanNotFound) {
+		t.Errorf("missing plan err = %v", err)
+	}
+}
+
+func TestRegenerateDayKeepsNeighbours(t *testing.T) {
+	store := storeWithPlan(t)
+
+	// İlk yanıt 1. günün kamp alanını tekrarlıyor, düzeltme turunda yeni alan geliyor
+	duplicate := `{"day": 9, "date": "2025-09-09", "location": {"name": "Kuşadası Yat Camping", "latitude": 37.865432, "longitude": 27.254321}}`
+	fixed := `{"day": 2, "date": "2025-08-02", "location": {"name": "Patara Kamp", "latitude": 36.26, "longitude": 29.31}}`
+	provider := NewFakeProvider(testModel, FakeTextResponse(duplicate), FakeTextResponse(fixed))
+	search := &recordingSearch{SearchProvider: fixtureSearch()}
+	service := &AIService{Provider: provider, Search: search, Store: store, MaxRepairRounds: 2}
+
+	revision, err := service.RegenerateDay(context.Background(), "p-1", 2, "")
+	if err != nil {
+		t.Fatalf("RegenerateDay: %v", err)
+	}
+
+	if provider.Calls() != 2 {
+		t.Errorf("provider calls = %d, want generation + 1 repair", provider.Calls())
+	}
+	if len(search.queries) != 2 || !strings.Contains(search.queries[0], "Kuşadası Yat Camping") {
+		t.Errorf("segment queries = %v", search.queries)
+	}
+	days := revision.Result.Plan.DailyPlan
+	if days[1].Location.Name != "Patara Kamp" || days[1].Date != "2025-08-02" {
+		t.Errorf("day 2 = %+v", days[1])
+	}
+	if !reflect.DeepEqual(days[0], validPlan().DailyPlan[0]) || !reflect.DeepEqual(days[2], validPlan().DailyPlan[2]) {
+		t.Errorf("neighbouring days changed: %+v", days)
+	}
+	if len(revision.Changes) != 1 || revision.Changes[0].Day != 2 || len(revision.Result.Violations) != 0 {
+		t.Errorf("changes = %+v, violations = %+v", revision.Changes, revision.Result.Violations)
+	}
+
+	if _, err := service.RegenerateDay(context.Background(), "p-1", 4, ""); !errors.Is(err, ErrDayOutOfRange) {
+		t.Errorf("day 4 err = %v, want ErrDayOutOfRange", err)
+	}
+}
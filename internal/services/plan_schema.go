@@ -0,0 +1,65 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"reflect"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// tripPlanSchema Gemini structured output için models.TripPlan'dan türetilen şema.
+// Model tipine alan eklendiğinde şema da otomatik güncellenir.
+var tripPlanSchema = schemaFor(reflect.TypeOf(models.TripPlan{}))
+
+// dailyPlanSchema tek gün yeniden üretilirken kullanılan şema
+var dailyPlanSchema = schemaFor(reflect.TypeOf(models.DailyPlan{}))
+
+// schemaFor bir Go tipini json tag'lerine göre genai.Schema'ya çevirir.
+//   - `json:"-"` veya `schema:"-"` olan alanlar şemaya girmez (sunucuda hesaplanan alanlar)
+//   - omitempty olmayan alanlar required kabul edilir
+//   - `description:"..."` tag'i alan açıklaması olarak modele iletilir
+func schemaFor(t reflect.Type) *genai.Schema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem())
+	case reflect.String:
+		return &genai.Schema{Type: genai.TypeString}
+	case reflect.Bool:
+		return &genai.Schema{Type: genai.TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &genai.Schema{Type: genai.TypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &genai.Schema{Type: genai.TypeNumber}
+	case reflect.Slice, reflect.Array:
+		return &genai.Schema{Type: genai.TypeArray, Items: schemaFor(t.Elem())}
+	case reflect.Struct:
+		schema := &genai.Schema{Type: genai.TypeObject, Properties: map[string]*genai.Schema{}}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("schema") == "-" {
+				continue
+			}
+
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			prop := schemaFor(field.Type)
+			prop.Description = field.Tag.Get("description")
+			schema.Properties[name] = prop
+			schema.PropertyOrdering = append(schema.PropertyOrdering, name)
+			if !strings.Contains(opts, "omitempty") {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+		return schema
+	default:
+		return &genai.Schema{Type: genai.TypeString}
+	}
+}
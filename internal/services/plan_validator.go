@@ -0,0 +1,130 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// İhlal kodları
+const (
+	ViolationDayCount          = "day_count"
+	ViolationDaySequence       = "day_sequence"
+	ViolationInvalidDate       = "invalid_date"
+	ViolationDateOutOfRange    = "date_out_of_range"
+	ViolationDateMismatch      = "date_mismatch"
+	ViolationMissingName       = "missing_name"
+	ViolationZeroCoordinates   = "zero_coordinates"
+	ViolationInvalidCoords     = "invalid_coordinates"
+	ViolationDuplicateCampsite = "duplicate_campsite"
+)
+
+// ValidatePlan planı istekteki bilgilere göre kontrol eder ve somut ihlallerin
+// listesini döndürür. Liste boşsa plan geçerlidir.
+func ValidatePlan(plan *models.TripPlan, prompt models.PromptBody) []models.PlanViolation {
+	violations := []models.PlanViolation{}
+	add := func(code string, day int, format string, args ...any) {
+		violations = append(violations, models.PlanViolation{Code: code, Day: day, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// Her gece için bir kayıt: bitiş tarihi ayrılış günüdür, o gece konaklanmaz
+	start, nights, datesKnown := promptNights(prompt)
+	end := start.AddDate(0, 0, nights)
+
+	if datesKnown {
+		if len(plan.DailyPlan) != nights {
+			add(ViolationDayCount, 0, "%s - %s arası %d gece var, her gece için bir kayıt olmalı; planda %d kayıt var", prompt.StartDate, prompt.EndDate, nights, len(plan.DailyPlan))
+		}
+	}
+
+	seenNames := map[string]int{}
+	seenCoords := map[string]int{}
+
+	for i, daily := range plan.DailyPlan {
+		day := daily.Day
+		if day != i+1 {
+			add(ViolationDaySequence, day, "%d. kayıt day=%d, %d olmalı", i+1, day, i+1)
+		}
+
+		date, err := time.Parse(dateLayout, daily.Date)
+		switch {
+		case err != nil:
+			add(ViolationInvalidDate, day, "tarih %q YYYY-MM-DD formatında değil", daily.Date)
+		case datesKnown && (date.Before(start) || !date.Before(end)):
+			add(ViolationDateOutOfRange, day, "tarih %s, %s - %s aralığının dışında", daily.Date, prompt.StartDate, prompt.EndDate)
+		case datesKnown && !date.Equal(start.AddDate(0, 0, i)):
+			add(ViolationDateMismatch, day, "%d. gün tarihi %s olmalı, %s verilmiş", i+1, start.AddDate(0, 0, i).Format(dateLayout), daily.Date)
+		}
+
+		loc := daily.Location
+		if strings.TrimSpace(loc.Name) == "" {
+			add(ViolationMissingName, day, "kamp alanı adı boş")
+		}
+
+		switch {
+		case loc.Latitude == 0 || loc.Longitude == 0:
+			add(ViolationZeroCoordinates, day, "%s için koordinat eksik (%.6f, %.6f)", loc.Name, loc.Latitude, loc.Longitude)
+		case math.Abs(loc.Latitude) > 90 || math.Abs(loc.Longitude) > 180:
+			add(ViolationInvalidCoords, day, "%s için koordinat geçersiz (%.6f, %.6f)", loc.Name, loc.Latitude, loc.Longitude)
+		}
+
+		// Aynı kamp alanı birden fazla güne yazılmamalı (isim veya konum tekrarı)
+		name := strings.ToLower(strings.TrimSpace(loc.Name))
+		coords := ""
+		if loc.Latitude != 0 && loc.Longitude != 0 {
+			coords = fmt.Sprintf("%.4f,%.4f", loc.Latitude, loc.Longitude)
+		}
+		if prev, ok := seenNames[name]; ok && name != "" {
+			add(ViolationDuplicateCampsite, day, "%s zaten %d. günde kullanılmış", loc.Name, prev)
+		} else if prev, ok := seenCoords[coords]; ok && coords != "" {
+			add(ViolationDuplicateCampsite, day, "%s koordinatları %d. gündeki kamp alanıyla aynı", loc.Name, prev)
+		}
+		if _, ok := seenNames[name]; !ok && name != "" {
+			seenNames[name] = day
+		}
+		if _, ok := seenCoords[coords]; !ok && coords != "" {
+			seenCoords[coords] = day
+		}
+	}
+
+	return violations
+}
+
+// validatePlan ValidatePlan'a ek olarak tercih kontrollerini ve yapılandırılmışsa
+// coğrafi, mesafe ve link kontrollerini uygular
+func (s *AIService) validatePlan(plan *models.TripPlan, prompt models.PromptBody) []models.PlanViolation {
+	violations := ValidatePlan(plan, prompt)
+	violations = append(violations, ValidatePreferences(plan, prompt)...)
+	if s.Geo != nil {
+		violations = append(violations, s.Geo.Validate(plan)...)
+	}
+	if s.Distances != nil {
+		violations = append(violations, s.Distances.Validate(plan, prompt)...)
+	}
+	if s.Links != nil {
+		violations = append(violations, s.Links.Validate(plan)...)
+	}
+	return violations
+}
+
+// Düzeltme turunda modele gönderilecek mesaj
+func repairPrompt(violations []models.PlanViolation) string {
+	return violationList(violations) + "\nBu hataları düzelterek planın tamamını aynı JSON formatında tekrar gönder."
+}
+
+func violationList(violations []models.PlanViolation) string {
+	var b strings.Builder
+	b.WriteString("Oluşturduğun planda şu hatalar var:\n")
+	for _, v := range violations {
+		if v.Day > 0 {
+			fmt.Fprintf(&b, "- [%s] Gün %d: %s\n", v.Code, v.Day, v.Message)
+		} else {
+			fmt.Fprintf(&b, "- [%s] %s\n", v.Code, v.Message)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"testing"
+)
+
+func validPlan() *models.TripPlan {
+	return &models.TripPlan{
+		DailyPlan: []models.DailyPlan{
+			{Day: 1, Date: "2025-08-01", Location: models.Location{Name: "Kuşadası Yat Camping", Latitude: 37.865432, Longitude: 27.254321}},
+			{Day: 2, Date: "2025-08-02", Location: models.Location{Name: "Kaş Camping", Latitude: 36.201234, Longitude: 29.631234}},
+			{Day: 3, Date: "2025-08-03", Location: models.Location{Name: "Olympos Orange Camp", Latitude: 36.398765, Longitude: 30.471234}},
+		},
+	}
+}
+
+func TestValidatePlan(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(plan *models.TripPlan)
+		want   []string
+	}{
+		{"valid", func(plan *models.TripPlan) {}, nil},
+		{"missing day", func(plan *models.TripPlan) {
+			plan.DailyPlan = plan.DailyPlan[:2]
+		}, []string{ViolationDayCount}},
+		{"date outside range", func(plan *models.TripPlan) {
+			plan.DailyPlan[2].Date = "2025-08-09"
+		}, []string{ViolationDateOutOfRange}},
+		{"entry for the departure day", func(plan *models.TripPlan) {
+			plan.DailyPlan = append(plan.DailyPlan, models.DailyPlan{Day: 4, Date: "2025-08-04", Location: models.Location{Name: "Antalya Kamp", Latitude: 36.89, Longitude: 30.71}})
+		}, []string{ViolationDayCount, ViolationDateOutOfRange}},
+		{"wrong date inside range", func(plan *models.TripPlan) {
+			plan.DailyPlan[1].Date = "2025-08-03"
+		}, []string{ViolationDateMismatch}},
+		{"zero coordinates", func(plan *models.TripPlan) {
+			plan.DailyPlan[0].Location.Latitude = 0
+		}, []string{ViolationZeroCoordinates}},
+		{"duplicated campsite by name", func(plan *models.TripPlan) {
+			plan.DailyPlan[2].Location.Name = "kaş camping "
+		}, []string{ViolationDuplicateCampsite}},
+		{"duplicated campsite by coordinates", func(plan *models.TripPlan) {
+			plan.DailyPlan[2].Location.Latitude = 36.20123
+			plan.DailyPlan[2].Location.Longitude = 29.63124
+		}, []string{ViolationDuplicateCampsite}},
+	}
+
+	prompt := models.PromptBody{StartDate: "2025-08-01", EndDate: "2025-08-04"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := validPlan()
+			tt.mutate(plan)
+
+			violations := ValidatePlan(plan, prompt)
+			if len(violations) != len(tt.want) {
+				t.Fatalf("violations = %+v, want codes %v", violations, tt.want)
+			}
+			for i, code := range tt.want {
+				if violations[i].Code != code {
+					t.Errorf("violation %d = %s, want %s", i, violations[i].Code, code)
+				}
+			}
+		})
+	}
+}
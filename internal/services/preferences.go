@@ -0,0 +1,159 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Tercih ihlal kodları
+const (
+	ViolationAccommodation  = "accommodation_mismatch"
+	ViolationAmenityMissing = "amenity_missing"
+	ViolationPetsNotAllowed = "pets_not_allowed"
+	ViolationOverBudget     = "over_budget"
+	ViolationGroupTooLarge  = "group_too_large"
+)
+
+const (
+	MAX_GROUP_SIZE     = 50
+	MAX_DAILY_DRIVE_KM = 2000
+)
+
+var accommodationTypes = []string{models.AccommodationTent, models.AccommodationCaravan, models.AccommodationBungalow, models.AccommodationGlamping}
+
+var amenityTypes = []string{models.AmenityElectricity, models.AmenityShowers, models.AmenityToilets, models.AmenityDrinkingWater, models.AmenityWifi, models.AmenityBeachAccess}
+
+// "Beach access", "beach-access" → "beach_access"
+func preferenceKey(s string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+// normalizePreferences tercihleri doğrular ve anahtarları normalize eder.
+// Hiçbir tercih belirtilmemişse nil döner, böylece prompt tercihsiz istekle aynı kalır.
+func normalizePreferences(p *models.TripPreferences) (*models.TripPreferences, error) {
+	if p == nil {
+		return nil, nil
+	}
+	normalized := *p
+
+	if normalized.Accommodation != "" {
+		normalized.Accommodation = preferenceKey(normalized.Accommodation)
+		if !slices.Contains(accommodationTypes, normalized.Accommodation) {
+			return nil, fmt.Errorf("%w: preferences.accommodation %q bilinmiyor (%s)", ErrInvalidPrompt, p.Accommodation, strings.Join(accommodationTypes, ", "))
+		}
+	}
+	if normalized.GroupSize < 0 || normalized.GroupSize > MAX_GROUP_SIZE {
+		return nil, fmt.Errorf("%w: preferences.group_size 0-%d arasında olmalı", ErrInvalidPrompt, MAX_GROUP_SIZE)
+	}
+	if normalized.BudgetPerNight < 0 {
+		return nil, fmt.Errorf("%w: preferences.budget_per_night negatif olamaz", ErrInvalidPrompt)
+	}
+	if normalized.MaxDailyDriveKm < 0 || normalized.MaxDailyDriveKm > MAX_DAILY_DRIVE_KM {
+		return nil, fmt.Errorf("%w: preferences.max_daily_drive_km 0-%d arasında olmalı", ErrInvalidPrompt, MAX_DAILY_DRIVE_KM)
+	}
+
+	normalized.Amenities = nil
+	for _, amenity := range p.Amenities {
+		key := preferenceKey(amenity)
+		if !slices.Contains(amenityTypes, key) {
+			return nil, fmt.Errorf("%w: preferences.amenities %q bilinmiyor (%s)", ErrInvalidPrompt, amenity, strings.Join(amenityTypes, ", "))
+		}
+		if !slices.Contains(normalized.Amenities, key) {
+			normalized.Amenities = append(normalized.Amenities, key)
+		}
+	}
+
+	if normalized.Accommodation == "" && normalized.GroupSize == 0 && !normalized.Pets &&
+		normalized.BudgetPerNight == 0 && len(normalized.Amenities) == 0 && normalized.MaxDailyDriveKm == 0 {
+		return nil, nil
+	}
+	return &normalized, nil
+}
+
+// preferencesSection tercihleri prompt'a eklenecek satırlara çevirir; tercih yoksa boş.
+// Kontrol edilebilen tercihler için modelden konak bilgilerini doldurması istenir.
+func preferencesSection(prompt models.PromptBody) string {
+	p := prompt.Preferences
+	if p == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\nTERCİHLER:\n")
+	if p.Accommodation != "" {
+		fmt.Fprintf(&b, "- Konaklama tipi: %s\n", p.Accommodation)
+	}
+	if p.GroupSize > 0 {
+		fmt.Fprintf(&b, "- Kişi sayısı: %d\n", p.GroupSize)
+	}
+	if p.Pets {
+		b.WriteString("- Evcil hayvanla seyahat: yalnızca evcil hayvan kabul eden alanlar\n")
+	}
+	if p.BudgetPerNight > 0 {
+		fmt.Fprintf(&b, "- Gecelik bütçe: en fazla %.0f TL\n", p.BudgetPerNight)
+	}
+	if len(p.Amenities) > 0 {
+		fmt.Fprintf(&b, "- Zorunlu olanaklar: %s\n", strings.Join(p.Amenities, ", "))
+	}
+	if p.MaxDailyDriveKm > 0 {
+		fmt.Fprintf(&b, "- Günlük en fazla sürüş: %.0f km\n", p.MaxDailyDriveKm)
+	}
+	if p.Accommodation != "" || p.GroupSize > 0 || p.Pets || p.BudgetPerNight > 0 || len(p.Amenities) > 0 {
+		b.WriteString("Her konak için location.accommodation, location.amenities, location.pets_allowed, location.price_per_night ve location.max_group_size alanlarını doldur; tercihlere uymayan alan seçme.")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// ValidatePreferences konakları modelin bildirdiği alan bilgilerine göre tercihlerle
+// karşılaştırır. Sadece bildirilen bilgi tercihe aykırıysa ihlal sayılır; boş
+// bırakılan alan (bilinmiyor) kontrol edilmez. Günlük sürüş sınırı
+// DistanceChecker'da uygulanır.
+func ValidatePreferences(plan *models.TripPlan, prompt models.PromptBody) []models.PlanViolation {
+	violations := []models.PlanViolation{}
+	p := prompt.Preferences
+	if p == nil {
+		return violations
+	}
+
+	for _, daily := range plan.DailyPlan {
+		loc := daily.Location
+		add := func(code string, format string, args ...any) {
+			violations = append(violations, models.PlanViolation{Code: code, Day: daily.Day, Message: fmt.Sprintf(format, args...)})
+		}
+
+		if p.Accommodation != "" && len(loc.Accommodation) > 0 && !containsKey(loc.Accommodation, p.Accommodation) {
+			add(ViolationAccommodation, "%d. gün %s %s konaklama sunmuyor (%s)", daily.Day, loc.Name, p.Accommodation, strings.Join(loc.Accommodation, ", "))
+		}
+		// Olanak listesi bildirildiyse alanın sunduklarının tamamı kabul edilir
+		missing := []string{}
+		for _, amenity := range p.Amenities {
+			if len(loc.Amenities) > 0 && !containsKey(loc.Amenities, amenity) {
+				missing = append(missing, amenity)
+			}
+		}
+		if len(missing) > 0 {
+			add(ViolationAmenityMissing, "%d. gün %s zorunlu olanakları sunmuyor: %s", daily.Day, loc.Name, strings.Join(missing, ", "))
+		}
+		if p.Pets && loc.PetsAllowed != nil && !*loc.PetsAllowed {
+			add(ViolationPetsNotAllowed, "%d. gün %s evcil hayvan kabul etmiyor", daily.Day, loc.Name)
+		}
+		if p.GroupSize > 0 && loc.MaxGroupSize > 0 && p.GroupSize > loc.MaxGroupSize {
+			add(ViolationGroupTooLarge, "%d. gün %s en fazla %d kişi kabul ediyor, grup %d kişi", daily.Day, loc.Name, loc.MaxGroupSize, p.GroupSize)
+		}
+		if p.BudgetPerNight > 0 && loc.PricePerNight > p.BudgetPerNight {
+			add(ViolationOverBudget, "%d. gün %s gecelik %.0f TL, bütçe en fazla %.0f TL", daily.Day, loc.Name, loc.PricePerNight, p.BudgetPerNight)
+		}
+	}
+	return violations
+}
+
+func containsKey(values []string, key string) bool {
+	for _, v := range values {
+		if preferenceKey(v) == key {
+			return true
+		}
+	}
+	return false
+}
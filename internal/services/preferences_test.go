@@ -0,0 +1,96 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"errors"
+	"testing"
+)
+
+func TestNormalizePreferences(t *testing.T) {
+	prompt := models.PromptBody{StartDate: "2025-08-01", EndDate: "2025-08-04", Preferences: &models.TripPreferences{
+		Accommodation: " Caravan", Amenities: []string{"Electricity", "beach-access", "electricity"}, GroupSize: 4,
+	}}
+	got, err := NormalizePrompt(prompt)
+	if err != nil {
+		t.Fatalf("NormalizePrompt: %v", err)
+	}
+	p := got.Preferences
+	if p.Accommodation != models.AccommodationCaravan || len(p.Amenities) != 2 || p.Amenities[1] != models.AmenityBeachAccess {
+		t.Errorf("preferences = %+v", p)
+	}
+
+	prompt.Preferences = &models.TripPreferences{}
+	if got, err := NormalizePrompt(prompt); err != nil || got.Preferences != nil {
+		t.Errorf("empty preferences = %+v, %v; want nil", got.Preferences, err)
+	}
+
+	for _, invalid := range []models.TripPreferences{
+		{Accommodation: "hotel"},
+		{GroupSize: -1},
+		{BudgetPerNight: -10},
+		{Amenities: []string{"sauna"}},
+		{MaxDailyDriveKm: MAX_DAILY_DRIVE_KM + 1},
+	} {
+		prompt.Preferences = &invalid
+		if _, err := NormalizePrompt(prompt); !errors.Is(err, ErrInvalidPrompt) {
+			t.Errorf("NormalizePrompt(%+v) err = %v, want ErrInvalidPrompt", invalid, err)
+		}
+	}
+}
+
+func TestValidatePreferences(t *testing.T) {
+	yes, no := true, false
+	plan := validPlan()
+	plan.DailyPlan[0].Location.Accommodation = []string{"tent", "Caravan"}
+	plan.DailyPlan[0].Location.Amenities = []string{"electricity", "Showers"}
+	plan.DailyPlan[0].Location.PetsAllowed = &yes
+	plan.DailyPlan[0].Location.PricePerNight = 600
+	plan.DailyPlan[0].Location.MaxGroupSize = 6
+	plan.DailyPlan[1].Location.Accommodation = []string{"tent"}
+	plan.DailyPlan[1].Location.Amenities = []string{"electricity"}
+	plan.DailyPlan[1].Location.PetsAllowed = &no
+	plan.DailyPlan[1].Location.PricePerNight = 1200
+	plan.DailyPlan[1].Location.MaxGroupSize = 2
+
+	prompt := models.PromptBody{Preferences: &models.TripPreferences{
+		Accommodation: models.AccommodationCaravan, Pets: true, BudgetPerNight: 800, GroupSize: 4,
+		Amenities: []string{models.AmenityElectricity, models.AmenityShowers},
+	}}
+	violations := ValidatePreferences(plan, prompt)
+
+	// 1. gün uygun; 2. gün her tercihe aykırı; 3. gün bilgi bildirilmemiş, aykırılık yok
+	want := []struct {
+		code string
+		day  int
+	}{
+		{ViolationAccommodation, 2}, {ViolationAmenityMissing, 2}, {ViolationPetsNotAllowed, 2}, {ViolationGroupTooLarge, 2}, {ViolationOverBudget, 2},
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("violations = %+v, want %d", violations, len(want))
+	}
+	for i, w := range want {
+		if violations[i].Code != w.code || violations[i].Day != w.day {
+			t.Errorf("violation %d = %s day %d, want %s day %d", i, violations[i].Code, violations[i].Day, w.code, w.day)
+		}
+	}
+
+	if got := ValidatePreferences(plan, models.PromptBody{}); len(got) != 0 {
+		t.Errorf("violations without preferences = %+v", got)
+	}
+}
+
+func TestDistanceLimitsFollowPreference(t *testing.T) {
+	checker := NewDistanceChecker()
+	prompt := models.PromptBody{Preferences: &models.TripPreferences{MaxDailyDriveKm: 150}}
+	if minKm, maxKm := checker.limits(prompt); minKm != 0 || maxKm != 150 {
+		t.Errorf("limits = %v, %v; want 0, 150", minKm, maxKm)
+	}
+	prompt.Preferences.MaxDailyDriveKm = 300
+	if minKm, maxKm := checker.limits(prompt); minKm != DAILY_MIN_KM || maxKm != 300 {
+		t.Errorf("limits = %v, %v; want %d, 300", minKm, maxKm, DAILY_MIN_KM)
+	}
+	prompt.Preferences.MaxDailyDriveKm = 900
+	if _, maxKm := checker.limits(prompt); maxKm != DAILY_MAX_KM {
+		t.Errorf("max = %v, want server limit %d", maxKm, DAILY_MAX_KM)
+	}
+}
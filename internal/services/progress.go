@@ -0,0 +1,89 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// ProgressFunc plan üretimi sırasında her aşamada çağrılır
+type ProgressFunc func(event models.ProgressEvent)
+
+type progressKey struct{}
+
+// WithProgress, context ile yapılan GenerateTripPlan çağrısının ilerleme
+// olaylarını fn'e iletir. fn üretim goroutine'inde çağrılır, bloklamamalıdır.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+func reportProgress(ctx context.Context, event models.ProgressEvent) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		fn(event)
+	}
+}
+
+func hasProgress(ctx context.Context) bool {
+	fn, ok := ctx.Value(progressKey{}).(ProgressFunc)
+	return ok && fn != nil
+}
+
+// generatePlanContent plan üretim çağrısını yapar. İlerleme dinleyen biri varsa
+// provider'ın streaming desteği kullanılır ve tamamlanan her daily_plan kaydı
+// partial_day olayı olarak yayınlanır. Dönen yanıt her iki durumda da aynıdır.
+func (s *AIService) generatePlanContent(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	if !hasProgress(ctx) {
+		return s.Provider.Generate(ctx, contents, config)
+	}
+
+	emitted := 0
+	onText := func(text string) {
+		days := partialDailyPlans(text)
+		for ; emitted < len(days); emitted++ {
+			day := days[emitted]
+			reportProgress(ctx, models.ProgressEvent{Stage: models.ProgressPartialDay, Day: &day})
+		}
+	}
+
+	streaming, ok := s.Provider.(StreamingProvider)
+	if !ok {
+		resp, err := s.Provider.Generate(ctx, contents, config)
+		if err == nil && resp != nil {
+			onText(resp.Text())
+		}
+		return resp, err
+	}
+	return streaming.GenerateStream(ctx, contents, config, onText)
+}
+
+// partialDailyPlans henüz tamamlanmamış bir JSON yanıtından daily_plan
+// dizisindeki tamamen gelmiş kayıtları çıkarır
+func partialDailyPlans(text string) []models.DailyPlan {
+	idx := strings.Index(text, `"daily_plan"`)
+	if idx < 0 {
+		return nil
+	}
+	rest := text[idx+len(`"daily_plan"`):]
+	start := strings.Index(rest, "[")
+	if start < 0 {
+		return nil
+	}
+
+	dec := json.NewDecoder(strings.NewReader(rest[start:]))
+	if _, err := dec.Token(); err != nil {
+		return nil
+	}
+
+	var days []models.DailyPlan
+	for dec.More() {
+		var day models.DailyPlan
+		if err := dec.Decode(&day); err != nil {
+			break
+		}
+		days = append(days, day)
+	}
+	return days
+}
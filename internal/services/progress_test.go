@@ -0,0 +1,107 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"context"
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+func TestProgressEventsReplay(t *testing.T) {
+	service := &AIService{Provider: replayProvider(t, "two_stage"), Search: fixtureSearch()}
+
+	var stages []string
+	var days []int
+	ctx := WithProgress(context.Background(), func(event models.ProgressEvent) {
+		stages = append(stages, event.Stage)
+		if event.Stage == models.ProgressPartialDay {
+			days = append(days, event.Day.Day)
+		}
+	})
+
+	if _, err := service.GenerateTripPlan(ctx, testPrompt()); err != nil {
+		t.Fatalf("GenerateTripPlan: %v", err)
+	}
+
+	count := map[string]int{}
+	for _, stage := range stages {
+		count[stage]++
+	}
+	if count[models.ProgressSearchQuery] != 3 || count[models.ProgressSearchResults] != 3 {
+		t.Errorf("search events = %v", stages)
+	}
+	if count[models.ProgressGenerationStarted] != 1 || count[models.ProgressValidation] != 1 {
+		t.Errorf("generation events = %v", stages)
+	}
+	if len(days) != 3 || days[0] != 1 || days[2] != 3 {
+		t.Errorf("partial days = %v", days)
+	}
+	if stages[len(stages)-1] != models.ProgressPlan {
+		t.Errorf("last stage = %s, want plan", stages[len(stages)-1])
+	}
+}
+
+func TestPartialDailyPlans(t *testing.T) {
+	full := `{"trip":{"name":"x"},"daily_plan":[{"day":1,"date":"2025-08-01","location":{"name":"A"}},{"day":2,"date":"2025-08-02","location":{"name":"B"}}]}`
+
+	tests := []struct {
+		cut  int
+		want int
+	}{
+		{len(`{"trip":{"name":"x"},"daily_`), 0},
+		{len(`{"trip":{"name":"x"},"daily_plan":[{"day":1,"date":"2025-08-01","location":{"na`), 0},
+		{len(`{"trip":{"name":"x"},"daily_plan":[{"day":1,"date":"2025-08-01","location":{"name":"A"}},{"day":2`), 1},
+		{len(full), 2},
+	}
+	for _, tt := range tests {
+		if got := partialDailyPlans(full[:tt.cut]); len(got) != tt.want {
+			t.Errorf("partialDailyPlans(%q) = %d days, want %d", full[:tt.cut], len(got), tt.want)
+		}
+	}
+}
+
+// Yanıtı iki parçada akıtan sağlayıcı
+type streamingFake struct {
+	*FakeProvider
+}
+
+func (p streamingFake) GenerateStream(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, onText func(text string)) (*genai.GenerateContentResponse, error) {
+	resp, err := p.Generate(ctx, contents, config)
+	if err != nil {
+		return nil, err
+	}
+	text := resp.Text()
+	onText(text[:len(text)/2])
+	onText(text)
+	return resp, nil
+}
+
+func TestRecordingProviderForwardsStreaming(t *testing.T) {
+	dir := t.TempDir()
+	contents := []*genai.Content{genai.NewContentFromText("plan", genai.RoleUser)}
+	var chunks []string
+	onText := func(text string) { chunks = append(chunks, text) }
+
+	var provider Provider = NewRecordingProvider(streamingFake{NewFakeProvider(testModel, FakeTextResponse(`{"daily_plan": []}`))}, dir)
+	streaming, ok := provider.(StreamingProvider)
+	if !ok {
+		t.Fatal("recording provider does not implement StreamingProvider")
+	}
+	if _, err := streaming.GenerateStream(context.Background(), contents, nil, onText); err != nil {
+		t.Fatalf("GenerateStream: %v", err)
+	}
+	if len(chunks) != 2 {
+		t.Errorf("recorded stream chunks = %q, want 2", chunks)
+	}
+
+	// Kaydedilen yanıt replay'de tek parça olarak gelir
+	chunks = nil
+	resp, err := NewReplayProvider(dir, testModel).GenerateStream(context.Background(), contents, nil, onText)
+	if err != nil {
+		t.Fatalf("replay GenerateStream: %v", err)
+	}
+	if len(chunks) != 1 || chunks[0] != resp.Text() || resp.Text() != `{"daily_plan": []}` {
+		t.Errorf("replayed chunks = %q", chunks)
+	}
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"google.golang.org/genai"
+)
+
+// ErrFixtureNotFound replay modunda isteğin hash'ine ait fixture yoksa döner
+var ErrFixtureNotFound = errors.New("replay fixture not found")
+
+// ReplayFixture diske yazılan tek bir istek/yanıt çifti
+type ReplayFixture struct {
+	Hash     string                         `json:"hash"`
+	Seq      int                            `json:"seq"`
+	Model    string                         `json:"model"`
+	Request  ReplayRequest                  `json:"request"`
+	Response *genai.GenerateContentResponse `json:"response"`
+}
+
+type ReplayRequest struct {
+	Contents []*genai.Content             `json:"contents"`
+	Config   *genai.GenerateContentConfig `json:"config,omitempty"`
+}
+
+// ReplayProvider GenerateContent çağrılarını fixture dosyalarına kaydeder
+// (Upstream set ise) veya istek hash'ine göre fixture'dan yanıt döndürür.
+// Böylece servis ağ erişimi olmadan deterministik olarak çalıştırılabilir.
+type ReplayProvider struct {
+	Dir      string
+	Model    string
+	Upstream Provider
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewReplayProvider sadece dizindeki fixture'lardan yanıt veren provider
+func NewReplayProvider(dir string, model string) *ReplayProvider {
+	return &ReplayProvider{Dir: dir, Model: model}
+}
+
+// NewRecordingProvider upstream'e giden her isteği ve yanıtını dir altına kaydeder
+func NewRecordingProvider(upstream Provider, dir string) *ReplayProvider {
+	return &ReplayProvider{Dir: dir, Model: upstream.ModelName(), Upstream: upstream}
+}
+
+func (p *ReplayProvider) ModelName() string {
+	return p.Model
+}
+
+func (p *ReplayProvider) Generate(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	return p.generate(ctx, contents, config, func() (*genai.GenerateContentResponse, error) {
+		return p.Upstream.Generate(ctx, contents, config)
+	})
+}
+
+// GenerateStream upstream akış destekliyorsa parçaları iletir ve birleşik yanıtı
+// kaydeder. Replay modunda veya upstream akış desteklemiyorsa onText tam metinle
+// bir kez çağrılır.
+func (p *ReplayProvider) GenerateStream(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, onText func(text string)) (*genai.GenerateContentResponse, error) {
+	streaming, ok := p.Upstream.(StreamingProvider)
+	if !ok {
+		resp, err := p.Generate(ctx, contents, config)
+		if err == nil && resp != nil {
+			onText(resp.Text())
+		}
+		return resp, err
+	}
+	return p.generate(ctx, contents, config, func() (*genai.GenerateContentResponse, error) {
+		return streaming.GenerateStream(ctx, contents, config, onText)
+	})
+}
+
+// Replay modunda fixture'dan döner, kayıt modunda call ile upstream'i çağırıp yanıtı kaydeder
+func (p *ReplayProvider) generate(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, call func() (*genai.GenerateContentResponse, error)) (*genai.GenerateContentResponse, error) {
+	request := ReplayRequest{Contents: contents, Config: config}
+	hash, err := requestHash(p.Model, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Upstream == nil {
+		fixture, err := p.load(hash)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("📼 Replay: %s", hash[:12])
+		return fixture.Response, nil
+	}
+
+	resp, err := call()
+	if err != nil {
+		// Hatalar kaydedilmiyor, replay'de fixture eksik olarak görünür
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.seq++
+	fixture := ReplayFixture{Hash: hash, Seq: p.seq, Model: p.Model, Request: request, Response: resp}
+	p.mu.Unlock()
+
+	if err := p.save(fixture); err != nil {
+		log.Printf("⚠️ Replay fixture could not be saved: %v", err)
+	} else {
+		log.Printf("📼 Recorded: %s", hash[:12])
+	}
+	return resp, nil
+}
+
+func (p *ReplayProvider) GenerateWithTools(ctx context.Context, contents []*genai.Content, config *genai.GenerateContentConfig, tools []*genai.Tool) (*genai.GenerateContentResponse, error) {
+	return p.Generate(ctx, contents, withTools(config, tools))
+}
+
+func (p *ReplayProvider) CountTokens(ctx context.Context, contents []*genai.Content) (int32, error) {
+	if p.Upstream != nil {
+		return p.Upstream.CountTokens(ctx, contents)
+	}
+	return estimateTokens(contents), nil
+}
+
+func (p *ReplayProvider) load(hash string) (*ReplayFixture, error) {
+	data, err := os.ReadFile(filepath.Join(p.Dir, hash+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrFixtureNotFound, hash)
+		}
+		return nil, err
+	}
+
+	var fixture ReplayFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("replay fixture %s is invalid: %w", hash, err)
+	}
+	return &fixture, nil
+}
+
+func (p *ReplayProvider) save(fixture ReplayFixture) error {
+	if err := os.MkdirAll(p.Dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(p.Dir, fixture.Hash+".json"), append(data, '\n'), 0o644)
+}
+
+// LoadReplayFixtures dizindeki tüm fixture'ları kayıt sırasına göre döndürür
+func LoadReplayFixtures(dir string) ([]ReplayFixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures []ReplayFixture
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var fixture ReplayFixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		fixtures = append(fixtures, fixture)
+	}
+
+	sort.Slice(fixtures, func(i, j int) bool { return fixtures[i].Seq < fixtures[j].Seq })
+	return fixtures, nil
+}
+
+// İstek hash'i model + içerik + config JSON'undan hesaplanır.
+// encoding/json map anahtarlarını sıraladığı için sonuç deterministik.
+func requestHash(model string, request ReplayRequest) (string, error) {
+	data, err := json.Marshal(struct {
+		Model   string        `json:"model"`
+		Request ReplayRequest `json:"request"`
+	}{model, request})
+	if err != nil {
+		return "", fmt.Errorf("replay request encode failed: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RerecordFixtures dizindeki fixture'ların yanıtlarını kayıt sırasıyla yeniden
+// oynatıp yeni istek hash'leriyle kaydeden bir provider döndürür. Prompt veya
+// config değiştiğinde, yanıtlar hâlâ geçerliyse canlı API'ye gitmeden
+// fixture'ları güncellemek için kullanılır.
+func RerecordFixtures(dir string, model string) (*ReplayProvider, error) {
+	fixtures, err := LoadReplayFixtures(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*genai.GenerateContentResponse
+	for _, fixture := range fixtures {
+		responses = append(responses, fixture.Response)
+		if err := os.Remove(filepath.Join(dir, fixture.Hash+".json")); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	return NewRecordingProvider(NewFakeProvider(model, responses...), dir), nil
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"ai-routes-service/internal/geo"
+	"ai-routes-service/internal/models"
+	"context"
+	"encoding/json"
+	"log"
+	"math"
+
+	"google.golang.org/genai"
+)
+
+// Sıranın değiştirilmesi için gereken en az kazanç
+const (
+	MIN_REORDER_GAIN_KM  = 10
+	MIN_REORDER_GAIN_PCT = 5
+)
+
+// RouteOptimizer konakların sırasını başlangıçtan bitişe toplam mesafeyi
+// en aza indirecek şekilde yeniden düzenler. Günler ve tarihler yerinde
+// kalır, sadece konumlar yer değiştirir.
+type RouteOptimizer struct {
+	MinGainKm  float64
+	MinGainPct float64
+}
+
+func NewRouteOptimizer() *RouteOptimizer {
+	return &RouteOptimizer{MinGainKm: MIN_REORDER_GAIN_KM, MinGainPct: MIN_REORDER_GAIN_PCT}
+}
+
+// Optimize planı yerinde düzenler. Koordinatı olmayan konak varsa sıra
+// değerlendirilemez ve nil döner.
+func (o *RouteOptimizer) Optimize(prompt models.PromptBody, plan *models.TripPlan) *models.RouteOptimization {
+	if len(plan.DailyPlan) < 2 {
+		return nil
+	}
+	stops := make([]geo.Point, len(plan.DailyPlan))
+	for i, daily := range plan.DailyPlan {
+		pt := locationPoint(daily.Location)
+		if pt == nil {
+			return nil
+		}
+		stops[i] = *pt
+	}
+	start := endpointPoint(plan.Trip.StartCoordinates, prompt.StartPosition)
+	end := endpointPoint(plan.Trip.EndCoordinates, prompt.EndPosition)
+
+	identity := make([]int, len(stops))
+	for i := range identity {
+		identity[i] = i
+	}
+	order := geo.OrderStops(start, end, stops)
+	originalKm := geo.PathKm(start, end, stops, identity)
+	optimizedKm := geo.PathKm(start, end, stops, order)
+
+	result := &models.RouteOptimization{
+		Order:         make([]int, len(order)),
+		OriginalKm:    round1(originalKm),
+		OptimizedKm:   round1(optimizedKm),
+		ImprovementKm: round1(originalKm - optimizedKm),
+	}
+	if originalKm > 0 {
+		result.ImprovementPct = math.Round((originalKm-optimizedKm)/originalKm*1000) / 10
+	}
+	for i, idx := range order {
+		result.Order[i] = plan.DailyPlan[idx].Day
+	}
+	if result.ImprovementKm < o.MinGainKm || result.ImprovementPct < o.MinGainPct {
+		return result
+	}
+
+	locations := make([]models.Location, len(order))
+	for i, idx := range order {
+		locations[i] = plan.DailyPlan[idx].Location
+	}
+	for i := range plan.DailyPlan {
+		plan.DailyPlan[i].Location = locations[i]
+	}
+	result.Applied = true
+	log.Printf("🧭 Konak sırası değiştirildi: %.0f km → %.0f km", originalKm, optimizedKm)
+	return result
+}
+
+// Sıra değiştiyse düzeltme turunda model, ihlallerin ait olduğu planı görmeli
+func planContent(plan *models.TripPlan, fallback *genai.Content) *genai.Content {
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return fallback
+	}
+	return genai.NewContentFromText(string(data), genai.RoleModel)
+}
+
+type fixedOrderKey struct{}
+
+// withFixedOrder kullanıcının belirlediği sıranın korunması gereken
+// üretimlerde (revizyon) sıra optimizasyonunu kapatır
+func withFixedOrder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fixedOrderKey{}, true)
+}
+
+// optimizeOrder yapılandırılmışsa ve context sırayı sabitlemiyorsa konak sırasını düzenler
+func (s *AIService) optimizeOrder(ctx context.Context, prompt models.PromptBody, plan *models.TripPlan) *models.RouteOptimization {
+	if s.Optimizer == nil {
+		return nil
+	}
+	if fixed, _ := ctx.Value(fixedOrderKey{}).(bool); fixed {
+		return nil
+	}
+	return s.Optimizer.Optimize(prompt, plan)
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"ai-routes-service/internal/models"
+	"context"
+	"testing"
+)
+
+func TestRouteOptimizer(t *testing.T) {
+	prompt := models.PromptBody{StartPosition: "Ankara", EndPosition: "Antalya"}
+	plan := &models.TripPlan{DailyPlan: []models.DailyPlan{
+		{Day: 1, Date: "2025-08-01", Location: models.Location{Name: "Kaş Camping", Latitude: 36.2012, Longitude: 29.6312}},
+		{Day: 2, Date: "2025-08-02", Location: models.Location{Name: "Afyon Kamp", Latitude: 38.7507, Longitude: 30.5567}},
+		{Day: 3, Date: "2025-08-03", Location: models.Location{Name: "Eğirdir Kamp", Latitude: 37.8746, Longitude: 30.8504}},
+	}}
+
+	result := NewRouteOptimizer().Optimize(prompt, plan)
+	if result == nil || !result.Applied || result.ImprovementKm <= 0 || result.OptimizedKm >= result.OriginalKm {
+		t.Fatalf("optimization = %+v", result)
+	}
+	if want := []int{2, 3, 1}; !equalOrder(result.Order, want) {
+		t.Errorf("order = %v, want %v", result.Order, want)
+	}
+	// Gün ve tarih yerinde kalır, konumlar yer değiştirir
+	if plan.DailyPlan[0].Day != 1 || plan.DailyPlan[0].Date != "2025-08-01" || plan.DailyPlan[0].Location.Name != "Afyon Kamp" ||
+		plan.DailyPlan[2].Location.Name != "Kaş Camping" {
+		t.Errorf("plan = %+v", plan.DailyPlan)
+	}
+
+	// İyileşme eşiğin altındaysa sadece raporlanır
+	again := NewRouteOptimizer().Optimize(prompt, plan)
+	if again == nil || again.Applied || again.ImprovementKm != 0 {
+		t.Errorf("second optimization = %+v", again)
+	}
+
+	// Revizyonlarda sıra sabit kalır
+	service := &AIService{Optimizer: NewRouteOptimizer()}
+	if got := service.optimizeOrder(withFixedOrder(context.Background()), prompt, plan); got != nil {
+		t.Errorf("fixed order optimized: %+v", got)
+	}
+}
+
+func equalOrder(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,268 @@
+package storage
+
+import (
+	"ai-routes-service/internal/geo"
+	"ai-routes-service/internal/models"
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+)
+
+// Tek sorguda dönecek en fazla kamp alanı
+const MAX_CATALOGUE_RESULTS = 500
+
+// CampsiteCatalogue yerel kamp alanı kataloğu
+type CampsiteCatalogue interface {
+	// UpsertCampsites kayıtları ID'ye göre ekler veya günceller, yazılan kayıt sayısını döndürür
+	UpsertCampsites(ctx context.Context, sites []models.Campsite) (int, error)
+	// CampsitesInBox kutu içindeki kamp alanlarını önce doğrulanmışlar olmak üzere döndürür
+	CampsitesInBox(ctx context.Context, box geo.BBox, limit int) ([]models.Campsite, error)
+	// CampsitesWithoutEmbedding model için vektörü olmayan veya vektörü eskimiş kayıtları döndürür
+	CampsitesWithoutEmbedding(ctx context.Context, model string, limit int) ([]models.Campsite, error)
+	SaveEmbeddings(ctx context.Context, model string, embeddings []Embedding) error
+	// Embeddings modelin tüm vektörlerini döndürür
+	Embeddings(ctx context.Context, model string) ([]Embedding, error)
+	Close() error
+}
+
+// Embedding kamp alanı metninin bir embedding modeliyle üretilmiş vektörü.
+// UpdatedAt vektörün üretildiği andaki kaydın updated_at değeridir; kayıt
+// güncellendiğinde vektör yeniden üretilir.
+type Embedding struct {
+	CampsiteID string
+	Vector     []float32
+	UpdatedAt  time.Time
+}
+
+// SQLCatalogue SQLite dosyasında tutulan katalog. Plan geçmişinden ayrı bir
+// dosyadır; import komutlarıyla doldurulur ve servis tarafından okunur.
+type SQLCatalogue struct {
+	db *sql.DB
+}
+
+func OpenCatalogue(path string) (*SQLCatalogue, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	schema := `CREATE TABLE IF NOT EXISTS campsites (
+	id         TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	address    TEXT NOT NULL DEFAULT '',
+	latitude   REAL NOT NULL,
+	longitude  REAL NOT NULL,
+	site_url   TEXT NOT NULL DEFAULT '',
+	amenities  TEXT NOT NULL DEFAULT '[]',
+	description TEXT NOT NULL DEFAULT '',
+	season     TEXT NOT NULL DEFAULT '',
+	source     TEXT NOT NULL DEFAULT '',
+	verified   INTEGER NOT NULL DEFAULT 0,
+	updated_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS campsites_lat_lon ON campsites (latitude, longitude);
+CREATE TABLE IF NOT EXISTS campsite_embeddings (
+	campsite_id TEXT NOT NULL,
+	model       TEXT NOT NULL,
+	vector      BLOB NOT NULL,
+	updated_at  TIMESTAMP NOT NULL,
+	PRIMARY KEY (campsite_id, model)
+);`
+
+	for _, stmt := range splitStatements(schema) {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("catalogue migration failed: %w", err)
+		}
+	}
+	// description sütunu sonradan eklendi; eski kataloglar için
+	if _, err := db.Exec(`ALTER TABLE campsites ADD COLUMN description TEXT NOT NULL DEFAULT ''`); err != nil && !strings.Contains(err.Error(), "duplicate column") {
+		db.Close()
+		return nil, fmt.Errorf("catalogue migration failed: %w", err)
+	}
+
+	log.Printf("🏕️ Kamp alanı kataloğu hazır: %s", path)
+	return &SQLCatalogue{db: db}, nil
+}
+
+// CampsiteID ID'si olmayan kayıtlar için isim ve yaklaşık konumdan kararlı bir ID üretir
+func CampsiteID(site models.Campsite) string {
+	if site.ID != "" {
+		return site.ID
+	}
+	key := fmt.Sprintf("%s|%.4f|%.4f", strings.ToLower(strings.TrimSpace(site.Name)), site.Latitude, site.Longitude)
+	sum := sha1.Sum([]byte(key))
+	return "site:" + hex.EncodeToString(sum[:8])
+}
+
+func (c *SQLCatalogue) UpsertCampsites(ctx context.Context, sites []models.Campsite) (int, error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Elle doğrulanmış kayıt, doğrulanmamış bir kaynaktan gelen güncellemeyle düşmez
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO campsites
+		(id, name, address, latitude, longitude, site_url, amenities, description, season, source, verified, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name, address = excluded.address,
+			latitude = excluded.latitude, longitude = excluded.longitude,
+			site_url = excluded.site_url, amenities = excluded.amenities, description = excluded.description,
+			season = excluded.season, source = excluded.source,
+			verified = MAX(campsites.verified, excluded.verified),
+			updated_at = excluded.updated_at`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	written := 0
+	for _, site := range sites {
+		if strings.TrimSpace(site.Name) == "" {
+			continue
+		}
+		amenities := site.Amenities
+		if amenities == nil {
+			amenities = []string{}
+		}
+		data, err := json.Marshal(amenities)
+		if err != nil {
+			return written, err
+		}
+		updated := site.UpdatedAt
+		if updated.IsZero() {
+			updated = time.Now()
+		}
+		if _, err := stmt.ExecContext(ctx, CampsiteID(site), site.Name, site.Address, site.Latitude, site.Longitude,
+			site.SiteURL, string(data), site.Description, site.Season, site.Source, site.Verified, updated.UTC()); err != nil {
+			return written, fmt.Errorf("kamp alanı kaydedilemedi (%s): %w", site.Name, err)
+		}
+		written++
+	}
+	return written, tx.Commit()
+}
+
+func (c *SQLCatalogue) CampsitesInBox(ctx context.Context, box geo.BBox, limit int) ([]models.Campsite, error) {
+	if limit <= 0 || limit > MAX_CATALOGUE_RESULTS {
+		limit = MAX_CATALOGUE_RESULTS
+	}
+	rows, err := c.db.QueryContext(ctx, `SELECT `+campsiteColumns+`
+		FROM campsites
+		WHERE latitude BETWEEN $1 AND $2 AND longitude BETWEEN $3 AND $4
+		ORDER BY verified DESC, id LIMIT $5`, box.MinLat, box.MaxLat, box.MinLon, box.MaxLon, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanCampsites(rows)
+}
+
+const campsiteColumns = `campsites.id, name, address, latitude, longitude, site_url, amenities, description, season, source, verified, campsites.updated_at`
+
+func scanCampsites(rows *sql.Rows) ([]models.Campsite, error) {
+	defer rows.Close()
+
+	sites := []models.Campsite{}
+	for rows.Next() {
+		var site models.Campsite
+		var amenities string
+		if err := rows.Scan(&site.ID, &site.Name, &site.Address, &site.Latitude, &site.Longitude, &site.SiteURL,
+			&amenities, &site.Description, &site.Season, &site.Source, &site.Verified, &site.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(amenities), &site.Amenities); err != nil {
+			return nil, fmt.Errorf("campsite %s amenities decode: %w", site.ID, err)
+		}
+		sites = append(sites, site)
+	}
+	return sites, rows.Err()
+}
+
+func (c *SQLCatalogue) CampsitesWithoutEmbedding(ctx context.Context, model string, limit int) ([]models.Campsite, error) {
+	if limit <= 0 || limit > MAX_CATALOGUE_RESULTS {
+		limit = MAX_CATALOGUE_RESULTS
+	}
+	rows, err := c.db.QueryContext(ctx, `SELECT `+campsiteColumns+`
+		FROM campsites
+		LEFT JOIN campsite_embeddings e ON e.campsite_id = campsites.id AND e.model = $1
+		WHERE e.campsite_id IS NULL OR e.updated_at != campsites.updated_at
+		ORDER BY campsites.id LIMIT $2`, model, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanCampsites(rows)
+}
+
+func (c *SQLCatalogue) SaveEmbeddings(ctx context.Context, model string, embeddings []Embedding) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, e := range embeddings {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO campsite_embeddings (campsite_id, model, vector, updated_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (campsite_id, model) DO UPDATE SET vector = excluded.vector, updated_at = excluded.updated_at`,
+			e.CampsiteID, model, encodeVector(e.Vector), e.UpdatedAt.UTC()); err != nil {
+			return fmt.Errorf("embedding kaydedilemedi (%s): %w", e.CampsiteID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (c *SQLCatalogue) Embeddings(ctx context.Context, model string) ([]Embedding, error) {
+	rows, err := c.db.QueryContext(ctx, `SELECT campsite_id, vector, updated_at FROM campsite_embeddings WHERE model = $1 ORDER BY campsite_id`, model)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	embeddings := []Embedding{}
+	for rows.Next() {
+		var e Embedding
+		var data []byte
+		if err := rows.Scan(&e.CampsiteID, &data, &e.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if e.Vector, err = decodeVector(data); err != nil {
+			return nil, fmt.Errorf("campsite %s embedding decode: %w", e.CampsiteID, err)
+		}
+		embeddings = append(embeddings, e)
+	}
+	return embeddings, rows.Err()
+}
+
+// Vektörler little-endian float32 dizisi olarak saklanır
+func encodeVector(v []float32) []byte {
+	data := make([]byte, 4*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(f))
+	}
+	return data
+}
+
+func decodeVector(data []byte) ([]float32, error) {
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("invalid vector length %d", len(data))
+	}
+	v := make([]float32, len(data)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+	return v, nil
+}
+
+func (c *SQLCatalogue) Close() error {
+	return c.db.Close()
+}
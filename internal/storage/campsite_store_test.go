@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"ai-routes-service/internal/geo"
+	"ai-routes-service/internal/models"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCatalogueUpsertAndBox(t *testing.T) {
+	catalogue, err := OpenCatalogue(filepath.Join(t.TempDir(), "catalogue.db"))
+	if err != nil {
+		t.Fatalf("OpenCatalogue: %v", err)
+	}
+	defer catalogue.Close()
+	ctx := context.Background()
+
+	sites := []models.Campsite{
+		{ID: "tkd:1", Name: "Kaş Camping", Latitude: 36.2012, Longitude: 29.6312, Amenities: []string{"duş", "elektrik"}, Verified: true},
+		{Name: "Olympos Orange Camp", Latitude: 36.3987, Longitude: 30.4712},
+		{Name: "Ayder Kamp", Latitude: 40.9523, Longitude: 41.1012},
+		{Name: "  ", Latitude: 36.5, Longitude: 30.0},
+	}
+	written, err := catalogue.UpsertCampsites(ctx, sites)
+	if err != nil || written != 3 {
+		t.Fatalf("UpsertCampsites = %d, %v; want 3", written, err)
+	}
+
+	// Doğrulanmamış kaynaktan gelen güncelleme doğrulamayı düşürmez
+	if _, err := catalogue.UpsertCampsites(ctx, []models.Campsite{{ID: "tkd:1", Name: "Kaş Camping", Address: "Kaş, Antalya", Latitude: 36.2012, Longitude: 29.6312}}); err != nil {
+		t.Fatalf("UpsertCampsites: %v", err)
+	}
+
+	got, err := catalogue.CampsitesInBox(ctx, geo.BBox{MinLat: 36, MaxLat: 37, MinLon: 29, MaxLon: 31}, 0)
+	if err != nil {
+		t.Fatalf("CampsitesInBox: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("sites = %+v, want 2", got)
+	}
+	if got[0].ID != "tkd:1" || !got[0].Verified || got[0].Address != "Kaş, Antalya" {
+		t.Errorf("first site = %+v, want updated verified Kaş Camping", got[0])
+	}
+	if got[1].ID != CampsiteID(sites[1]) || got[1].Amenities == nil {
+		t.Errorf("second site = %+v", got[1])
+	}
+}
+
+func TestCatalogueEmbeddings(t *testing.T) {
+	catalogue, err := OpenCatalogue(filepath.Join(t.TempDir(), "catalogue.db"))
+	if err != nil {
+		t.Fatalf("OpenCatalogue: %v", err)
+	}
+	defer catalogue.Close()
+	ctx := context.Background()
+	updated := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	sites := []models.Campsite{
+		{ID: "a", Name: "Göl Kamp", Description: "Göl kenarı", Latitude: 37.8, Longitude: 30.8, UpdatedAt: updated},
+		{ID: "b", Name: "Deniz Kamp", Latitude: 36.2, Longitude: 29.6, UpdatedAt: updated},
+	}
+	if _, err := catalogue.UpsertCampsites(ctx, sites); err != nil {
+		t.Fatalf("UpsertCampsites: %v", err)
+	}
+	if err := catalogue.SaveEmbeddings(ctx, "m", []Embedding{{CampsiteID: "a", Vector: []float32{0.5, -1, 2}, UpdatedAt: updated}}); err != nil {
+		t.Fatalf("SaveEmbeddings: %v", err)
+	}
+
+	missing, err := catalogue.CampsitesWithoutEmbedding(ctx, "m", 0)
+	if err != nil || len(missing) != 1 || missing[0].ID != "b" {
+		t.Fatalf("CampsitesWithoutEmbedding = %+v, %v; want b", missing, err)
+	}
+	if other, _ := catalogue.CampsitesWithoutEmbedding(ctx, "other", 0); len(other) != 2 {
+		t.Errorf("other model missing = %d, want 2", len(other))
+	}
+
+	embeddings, err := catalogue.Embeddings(ctx, "m")
+	if err != nil || len(embeddings) != 1 || embeddings[0].Vector[1] != -1 || embeddings[0].Vector[2] != 2 {
+		t.Fatalf("Embeddings = %+v, %v", embeddings, err)
+	}
+
+	// Kayıt güncellenince vektörü eskimiş sayılır
+	sites[0].Description = "Göl kenarı, sessiz"
+	sites[0].UpdatedAt = updated.Add(time.Hour)
+	if _, err := catalogue.UpsertCampsites(ctx, sites[:1]); err != nil {
+		t.Fatalf("UpsertCampsites: %v", err)
+	}
+	if missing, _ := catalogue.CampsitesWithoutEmbedding(ctx, "m", 0); len(missing) != 2 || missing[0].Description != "Göl kenarı, sessiz" {
+		t.Errorf("missing after update = %+v, want a and b", missing)
+	}
+}
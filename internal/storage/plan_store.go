@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"ai-routes-service/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	_ "github.com/glebarez/go-sqlite"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+
+	DEFAULT_LIST_LIMIT = 20
+	MAX_LIST_LIMIT     = 100
+)
+
+var ErrPlanNotFound = errors.New("plan not found")
+
+// PlanStore üretilen planları ve isteklerini saklar
+type PlanStore interface {
+	SavePlan(ctx context.Context, record *models.PlanRecord) error
+	GetPlan(ctx context.Context, id string) (*models.PlanRecord, error)
+	// ListPlans kullanıcının planlarını yeniden eskiye sıralı döndürür
+	ListPlans(ctx context.Context, userID string, limit, offset int) ([]models.PlanRecord, error)
+	Close() error
+}
+
+// SQLStore SQLite ve Postgres için ortak PlanStore. Sorgularda iki sürücünün
+// de desteklediği $N parametreleri kullanılır; JSON alanları TEXT olarak tutulur.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// Open veritabanına bağlanır ve tabloyu oluşturur.
+// driver "sqlite" (dsn dosya yolu) veya "postgres" (dsn bağlantı URL'si) olabilir.
+func Open(driver string, dsn string) (*SQLStore, error) {
+	var sqlDriver, timestampType string
+	switch driver {
+	case DriverSQLite:
+		sqlDriver, timestampType = "sqlite", "TIMESTAMP"
+	case DriverPostgres:
+		sqlDriver, timestampType = "pgx", "TIMESTAMPTZ"
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", driver)
+	}
+
+	db, err := sql.Open(sqlDriver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if driver == DriverSQLite {
+		// SQLite aynı anda tek yazıcıya izin verir
+		db.SetMaxOpenConns(1)
+	}
+
+	schema := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS plans (
+	id          TEXT PRIMARY KEY,
+	user_id     TEXT NOT NULL,
+	parent_id   TEXT NOT NULL DEFAULT '',
+	version     INTEGER NOT NULL DEFAULT 1,
+	instruction TEXT NOT NULL DEFAULT '',
+	prompt      TEXT NOT NULL,
+	result      TEXT,
+	error       TEXT NOT NULL DEFAULT '',
+	model       TEXT NOT NULL,
+	started_at  %[1]s NOT NULL,
+	finished_at %[1]s NOT NULL,
+	duration_ms BIGINT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS plans_user_started ON plans (user_id, started_at DESC);`, timestampType)
+
+	for _, stmt := range splitStatements(schema) {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("storage migration failed: %w", err)
+		}
+	}
+	// Revizyon sütunları sonradan eklendi; eski plans tabloları için
+	for _, column := range []string{
+		"parent_id TEXT NOT NULL DEFAULT ''",
+		"version INTEGER NOT NULL DEFAULT 1",
+		"instruction TEXT NOT NULL DEFAULT ''",
+	} {
+		if err := addColumn(db, driver, "plans", column); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("storage migration failed: %w", err)
+		}
+	}
+
+	log.Printf("🗄️ Plan storage hazır: %s", driver)
+	return &SQLStore{db: db}, nil
+}
+
+func (s *SQLStore) SavePlan(ctx context.Context, record *models.PlanRecord) error {
+	prompt, err := json.Marshal(record.Prompt)
+	if err != nil {
+		return err
+	}
+	var result sql.NullString
+	if record.Result != nil {
+		data, err := json.Marshal(record.Result)
+		if err != nil {
+			return err
+		}
+		result = sql.NullString{String: string(data), Valid: true}
+	}
+
+	version := record.Version
+	if version == 0 {
+		version = 1
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO plans
+		(id, user_id, parent_id, version, instruction, prompt, result, error, model, started_at, finished_at, duration_ms)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		record.ID, record.UserID, record.ParentID, version, record.Instruction, string(prompt), result, record.Error, record.Model,
+		record.StartedAt.UTC(), record.FinishedAt.UTC(), record.DurationMs)
+	if err != nil {
+		return fmt.Errorf("plan kaydedilemedi: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) GetPlan(ctx context.Context, id string) (*models.PlanRecord, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+planColumns+` FROM plans WHERE id = $1`, id)
+	record, err := scanPlan(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPlanNotFound
+	}
+	return record, err
+}
+
+func (s *SQLStore) ListPlans(ctx context.Context, userID string, limit, offset int) ([]models.PlanRecord, error) {
+	if limit <= 0 {
+		limit = DEFAULT_LIST_LIMIT
+	}
+	if limit > MAX_LIST_LIMIT {
+		limit = MAX_LIST_LIMIT
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+planColumns+` FROM plans
+		WHERE user_id = $1 ORDER BY started_at DESC LIMIT $2 OFFSET $3`, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []models.PlanRecord{}
+	for rows.Next() {
+		record, err := scanPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *record)
+	}
+	return records, rows.Err()
+}
+
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+const planColumns = `id, user_id, parent_id, version, instruction, prompt, result, error, model, started_at, finished_at, duration_ms`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPlan(row rowScanner) (*models.PlanRecord, error) {
+	var record models.PlanRecord
+	var prompt string
+	var result sql.NullString
+	if err := row.Scan(&record.ID, &record.UserID, &record.ParentID, &record.Version, &record.Instruction, &prompt, &result, &record.Error, &record.Model,
+		&record.StartedAt, &record.FinishedAt, &record.DurationMs); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(prompt), &record.Prompt); err != nil {
+		return nil, fmt.Errorf("plan %s prompt decode: %w", record.ID, err)
+	}
+	if result.Valid {
+		record.Result = &models.PlanResult{}
+		if err := json.Unmarshal([]byte(result.String), record.Result); err != nil {
+			return nil, fmt.Errorf("plan %s result decode: %w", record.ID, err)
+		}
+	}
+	return &record, nil
+}
+
+// addColumn sütun yoksa ekler. Postgres IF NOT EXISTS'i destekler, SQLite'ta
+// sütun zaten varsa dönen "duplicate column" hatası yok sayılır.
+func addColumn(db *sql.DB, driver, table, column string) error {
+	if driver == DriverPostgres {
+		_, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s", table, column))
+		return err
+	}
+	_, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, column))
+	if err != nil && strings.Contains(err.Error(), "duplicate column") {
+		return nil
+	}
+	return err
+}
+
+// Şema metnini tek tek çalıştırılabilecek ifadelere böler
+func splitStatements(schema string) []string {
+	var statements []string
+	for _, stmt := range strings.Split(schema, ";") {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"ai-routes-service/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T) *SQLStore {
+	t.Helper()
+	store, err := Open(DriverSQLite, filepath.Join(t.TempDir(), "plans.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSQLiteStoreRoundTrip(t *testing.T) {
+	store := openTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	records := []*models.PlanRecord{
+		{ID: "p-1", UserID: "u-1", Prompt: models.PromptBody{UserID: "u-1", Name: "Ege"}, Model: "gemini-test",
+			Result:    &models.PlanResult{Plan: &models.TripPlan{Trip: models.Trip{TotalDays: 3}}, Status: models.PlanStatusOK, Violations: []models.PlanViolation{}},
+			StartedAt: base, FinishedAt: base.Add(40 * time.Second), DurationMs: 40000},
+		{ID: "p-2", UserID: "u-1", Prompt: models.PromptBody{UserID: "u-1", Name: "Karadeniz"}, Model: "gemini-test",
+			Error: "context canceled", StartedAt: base.Add(time.Hour), FinishedAt: base.Add(time.Hour), DurationMs: 5},
+		{ID: "p-3", UserID: "u-2", Prompt: models.PromptBody{UserID: "u-2"}, Model: "gemini-test",
+			StartedAt: base, FinishedAt: base},
+	}
+	for _, record := range records {
+		if err := store.SavePlan(ctx, record); err != nil {
+			t.Fatalf("SavePlan(%s): %v", record.ID, err)
+		}
+	}
+
+	plans, err := store.ListPlans(ctx, "u-1", 0, 0)
+	if err != nil {
+		t.Fatalf("ListPlans: %v", err)
+	}
+	if len(plans) != 2 || plans[0].ID != "p-2" || plans[1].ID != "p-1" {
+		t.Fatalf("plans = %+v, want p-2, p-1", plans)
+	}
+	if plans[0].Result != nil || plans[0].Error != "context canceled" {
+		t.Errorf("failed plan = %+v", plans[0])
+	}
+	got := plans[1]
+	if got.Result == nil || got.Result.Plan.Trip.TotalDays != 3 || got.Prompt.Name != "Ege" {
+		t.Errorf("plan = %+v", got)
+	}
+	if !got.StartedAt.Equal(base) || got.DurationMs != 40000 {
+		t.Errorf("timings = %v / %d", got.StartedAt, got.DurationMs)
+	}
+
+	page, err := store.ListPlans(ctx, "u-1", 1, 1)
+	if err != nil || len(page) != 1 || page[0].ID != "p-1" {
+		t.Errorf("page = %+v, %v", page, err)
+	}
+
+	if _, err := store.GetPlan(ctx, "missing"); !errors.Is(err, ErrPlanNotFound) {
+		t.Errorf("GetPlan(missing) err = %v, want ErrPlanNotFound", err)
+	}
+}
+
+func TestSQLiteStoreMigratesPlanHistorySchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plans.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Revizyon sütunlarından önceki şema
+	if _, err := db.Exec(`CREATE TABLE plans (
+	id          TEXT PRIMARY KEY,
+	user_id     TEXT NOT NULL,
+	prompt      TEXT NOT NULL,
+	result      TEXT,
+	error       TEXT NOT NULL DEFAULT '',
+	model       TEXT NOT NULL,
+	started_at  TIMESTAMP NOT NULL,
+	finished_at TIMESTAMP NOT NULL,
+	duration_ms BIGINT NOT NULL
+)`); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	for i := 0; i < 2; i++ {
+		store, err := Open(DriverSQLite, path)
+		if err != nil {
+			t.Fatalf("Open #%d: %v", i+1, err)
+		}
+		store.Close()
+	}
+	store, err := Open(DriverSQLite, path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	now := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+	record := &models.PlanRecord{ID: "p-2", UserID: "u-1", ParentID: "p-1", Version: 2, Instruction: "2. günü değiştir",
+		Model: "gemini-test", StartedAt: now, FinishedAt: now}
+	if err := store.SavePlan(ctx, record); err != nil {
+		t.Fatalf("SavePlan: %v", err)
+	}
+	got, err := store.GetPlan(ctx, "p-2")
+	if err != nil {
+		t.Fatalf("GetPlan: %v", err)
+	}
+	if got.ParentID != "p-1" || got.Version != 2 || got.Instruction != "2. günü değiştir" {
+		t.Errorf("plan = %+v", got)
+	}
+}
@@ -0,0 +1,241 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoGeocodeResult sorgu için sonuç bulunamadığında döner
+var ErrNoGeocodeResult = errors.New("no geocode result")
+
+const (
+	GEOCODE_CACHE_SIZE = 4096
+	GEOCODE_CACHE_TTL  = 24 * time.Hour
+	// Aday sorgularında istenecek en fazla sonuç
+	GEOCODE_CANDIDATES = 5
+)
+
+type GeocodeResult struct {
+	Latitude    float64
+	Longitude   float64
+	DisplayName string
+	// OSM sınıfı ve tipi, örn. tourism / camp_site; bilinmiyorsa boş
+	Class string
+	Type  string
+}
+
+// Geocoder adres veya yer adını koordinata çevirir
+type Geocoder interface {
+	Geocode(ctx context.Context, query string) (*GeocodeResult, error)
+}
+
+// CandidateGeocoder sorgu için önem sırasına göre birden fazla aday döndürebilir
+type CandidateGeocoder interface {
+	Geocoder
+	GeocodeCandidates(ctx context.Context, query string) ([]GeocodeResult, error)
+}
+
+// NominatimGeocoder Nominatim uyumlu /search API'sini kullanır (yerel instance,
+// public sunucu veya stub). Public sunucunun kullanım kuralları gereği istekler
+// arasında MinInterval beklenir ve UserAgent gönderilir; sonuçlar CacheTTL boyunca,
+// en fazla CacheSize kayıt olarak bellekte tutulur.
+type NominatimGeocoder struct {
+	BaseURL   string
+	UserAgent string
+	// Virgülle ayrılmış ISO ülke kodları, örn. "tr"; boşsa kısıt yok
+	CountryCodes string
+	MinInterval  time.Duration
+	CacheSize    int
+	CacheTTL     time.Duration
+	HTTPClient   *http.Client
+
+	// mu yalnızca önbelleği ve sıradaki istek zamanını korur, HTTP isteği sırasında tutulmaz
+	mu    sync.Mutex
+	next  time.Time
+	cache map[string]geocodeEntry
+}
+
+// Sonuçsuz sorgular da boş results ile önbelleğe alınır
+type geocodeEntry struct {
+	results []GeocodeResult
+	expires time.Time
+}
+
+type nominatimPlace struct {
+	Lat         string `json:"lat"`
+	Lon         string `json:"lon"`
+	DisplayName string `json:"display_name"`
+	// jsonv2 formatı sınıfı "category" olarak döndürür
+	Category string `json:"category"`
+	Class    string `json:"class"`
+	Type     string `json:"type"`
+}
+
+func NewNominatimGeocoder(baseURL string, userAgent string) *NominatimGeocoder {
+	return &NominatimGeocoder{
+		BaseURL:     strings.TrimRight(baseURL, "/"),
+		UserAgent:   userAgent,
+		MinInterval: 1 * time.Second,
+		CacheSize:   GEOCODE_CACHE_SIZE,
+		CacheTTL:    GEOCODE_CACHE_TTL,
+		HTTPClient:  &http.Client{Timeout: 10 * time.Second},
+		cache:       map[string]geocodeEntry{},
+	}
+}
+
+func (g *NominatimGeocoder) Geocode(ctx context.Context, query string) (*GeocodeResult, error) {
+	results, err := g.GeocodeCandidates(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &results[0], nil
+}
+
+// GeocodeCandidates Nominatim'in önem sırasıyla en fazla GEOCODE_CANDIDATES sonuç döndürür
+func (g *NominatimGeocoder) GeocodeCandidates(ctx context.Context, query string) ([]GeocodeResult, error) {
+	key := strings.ToLower(strings.TrimSpace(query))
+	if key == "" {
+		return nil, ErrNoGeocodeResult
+	}
+
+	if entry, ok := g.cached(key); ok {
+		if len(entry.results) == 0 {
+			return nil, ErrNoGeocodeResult
+		}
+		return entry.results, nil
+	}
+	if err := g.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	results, err := g.search(ctx, query)
+	if err != nil && !errors.Is(err, ErrNoGeocodeResult) {
+		return nil, err
+	}
+	g.store(key, results)
+	return results, err
+}
+
+func (g *NominatimGeocoder) cached(key string) (geocodeEntry, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	entry, ok := g.cache[key]
+	if ok && time.Now().After(entry.expires) {
+		delete(g.cache, key)
+		return entry, false
+	}
+	return entry, ok
+}
+
+// Önbellek doluysa önce süresi dolanlar, yetmezse süresi en yakın olan silinir
+func (g *NominatimGeocoder) store(key string, results []GeocodeResult) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cache == nil {
+		g.cache = map[string]geocodeEntry{}
+	}
+	now := time.Now()
+	if _, ok := g.cache[key]; !ok && g.CacheSize > 0 && len(g.cache) >= g.CacheSize {
+		oldestKey, oldest := "", time.Time{}
+		for k, entry := range g.cache {
+			if now.After(entry.expires) {
+				delete(g.cache, k)
+			} else if oldestKey == "" || entry.expires.Before(oldest) {
+				oldestKey, oldest = k, entry.expires
+			}
+		}
+		if len(g.cache) >= g.CacheSize {
+			delete(g.cache, oldestKey)
+		}
+	}
+	g.cache[key] = geocodeEntry{results: results, expires: now.Add(g.CacheTTL)}
+}
+
+// wait kilit altında bir sonraki istek zamanını ayırır, beklemeyi kilit dışında yapar;
+// böylece hız sınırı tüm çağıranlar için geçerli olur ve iptal edilen ctx beklemeyi keser
+func (g *NominatimGeocoder) wait(ctx context.Context) error {
+	g.mu.Lock()
+	now := time.Now()
+	slot := g.next
+	if slot.Before(now) {
+		slot = now
+	}
+	g.next = slot.Add(g.MinInterval)
+	g.mu.Unlock()
+
+	if wait := time.Until(slot); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
+
+func (g *NominatimGeocoder) search(ctx context.Context, query string) ([]GeocodeResult, error) {
+	params := url.Values{}
+	params.Add("q", query)
+	params.Add("format", "jsonv2")
+	params.Add("limit", strconv.Itoa(GEOCODE_CANDIDATES))
+	if g.CountryCodes != "" {
+		params.Add("countrycodes", g.CountryCodes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.BaseURL+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP isteği oluşturulurken hata oluştu: %w", err)
+	}
+	if g.UserAgent != "" {
+		req.Header.Set("User-Agent", g.UserAgent)
+	}
+
+	resp, err := g.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP isteği gönderilirken hata oluştu: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Yanıt gövdesi okunurken hata oluştu: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Nominatim hatası: Durum kodu %d, Mesaj: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var places []nominatimPlace
+	if err := json.Unmarshal(bodyBytes, &places); err != nil {
+		return nil, fmt.Errorf("JSON ayrıştırılırken hata oluştu: %w", err)
+	}
+	if len(places) == 0 {
+		return nil, ErrNoGeocodeResult
+	}
+
+	results := make([]GeocodeResult, 0, len(places))
+	for _, place := range places {
+		lat, latErr := strconv.ParseFloat(place.Lat, 64)
+		lon, lonErr := strconv.ParseFloat(place.Lon, 64)
+		if latErr != nil || lonErr != nil {
+			return nil, fmt.Errorf("Nominatim koordinatı geçersiz: %q, %q", place.Lat, place.Lon)
+		}
+		class := place.Category
+		if class == "" {
+			class = place.Class
+		}
+		results = append(results, GeocodeResult{Latitude: lat, Longitude: lon, DisplayName: place.DisplayName, Class: class, Type: place.Type})
+	}
+	return results, nil
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,14 +22,42 @@ type SearchResult struct {
 	} `json:"items"`
 }
 
+// GoogleSearchProvider Google Custom Search API'sini kullanır
+type GoogleSearchProvider struct {
+	APIKey string
+	CX     string
+}
+
+func NewGoogleSearchProvider(apiKey, cx string) *GoogleSearchProvider {
+	return &GoogleSearchProvider{APIKey: apiKey, CX: cx}
+}
+
+func (p *GoogleSearchProvider) Search(ctx context.Context, query string) ([]SearchItem, error) {
+	result, err := PerformSearch(ctx, query, p.APIKey, p.CX)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]SearchItem, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, SearchItem{Title: item.Title, Link: item.Link, Snippet: item.Snippet})
+	}
+	return items, nil
+}
+
 // PerformSearch Google Custom Search API'sini çağırır ve sonuçları döndürür.
-func PerformSearch(query, apiKey, cx string) (*SearchResult, error) {
+func PerformSearch(ctx context.Context, query, apiKey, cx string) (*SearchResult, error) {
 	params := url.Values{}
 	params.Add("key", apiKey)
 	params.Add("cx", cx)
 	params.Add("q", query) // Aranacak terim
 
-	resp, err := http.Get(googleSearchAPIURL + "?" + params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, googleSearchAPIURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP isteği oluşturulurken hata oluştu: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP isteği gönderilirken hata oluştu: %w", err)
 	}
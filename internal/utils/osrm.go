@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"ai-routes-service/internal/geo"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Router iki nokta arasındaki karayolu mesafesini hesaplar
+type Router interface {
+	RouteDistanceKm(ctx context.Context, from, to geo.Point) (float64, error)
+}
+
+// OSRMRouter OSRM uyumlu /route API'sini kullanır (kendi sunucumuz veya stub)
+type OSRMRouter struct {
+	BaseURL string
+	// OSRM profili, örn. "driving"
+	Profile    string
+	HTTPClient *http.Client
+}
+
+type osrmResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Routes  []struct {
+		// Metre cinsinden
+		Distance float64 `json:"distance"`
+	} `json:"routes"`
+}
+
+func NewOSRMRouter(baseURL string) *OSRMRouter {
+	return &OSRMRouter{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Profile:    "driving",
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (r *OSRMRouter) RouteDistanceKm(ctx context.Context, from, to geo.Point) (float64, error) {
+	// OSRM koordinatları boylam,enlem sırasıyla bekler
+	endpoint := fmt.Sprintf("%s/route/v1/%s/%.6f,%.6f;%.6f,%.6f?overview=false",
+		r.BaseURL, r.Profile, from.Lon, from.Lat, to.Lon, to.Lat)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("HTTP isteği oluşturulurken hata oluştu: %w", err)
+	}
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("HTTP isteği gönderilirken hata oluştu: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("Yanıt gövdesi okunurken hata oluştu: %w", err)
+	}
+
+	// OSRM hata durumlarında da (ör. NoRoute) JSON gövde döner
+	var result osrmResponse
+	if err := json.Unmarshal(bodyBytes, &result); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return 0, fmt.Errorf("OSRM hatası: Durum kodu %d, Mesaj: %s", resp.StatusCode, string(bodyBytes))
+		}
+		return 0, fmt.Errorf("JSON ayrıştırılırken hata oluştu: %w", err)
+	}
+	if result.Code != "Ok" || len(result.Routes) == 0 {
+		return 0, fmt.Errorf("OSRM hatası: Durum kodu %d, Kod: %s, Mesaj: %s", resp.StatusCode, result.Code, result.Message)
+	}
+	return result.Routes[0].Distance / 1000, nil
+}
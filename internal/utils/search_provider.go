@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// SearchItem arama sağlayıcısından bağımsız tek bir arama sonucu
+type SearchItem struct {
+	Title   string `json:"title"`
+	Link    string `json:"link"`
+	Snippet string `json:"snippet"`
+}
+
+// SearchProvider web araması yapan arka uçları soyutlar
+type SearchProvider interface {
+	Search(ctx context.Context, query string) ([]SearchItem, error)
+}
+
+// FixtureSearchProvider sonuçları bir dizindeki JSON dosyalarından okur.
+// Her sorgu için <slug>.json aranır, yoksa default.json kullanılır.
+// Dosya içeriği []SearchItem formatındadır.
+type FixtureSearchProvider struct {
+	Dir string
+}
+
+func NewFixtureSearchProvider(dir string) *FixtureSearchProvider {
+	return &FixtureSearchProvider{Dir: dir}
+}
+
+func (p *FixtureSearchProvider) Search(ctx context.Context, query string) ([]SearchItem, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, name := range []string{QuerySlug(query), "default"} {
+		bytes, err := os.ReadFile(filepath.Join(p.Dir, name+".json"))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("fixture dosyası okunurken hata oluştu: %w", err)
+		}
+
+		var items []SearchItem
+		if err := json.Unmarshal(bytes, &items); err != nil {
+			return nil, fmt.Errorf("fixture JSON ayrıştırılırken hata oluştu (%s): %w", name, err)
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("'%s' için fixture bulunamadı", query)
+}
+
+// QuerySlug sorguyu dosya adı olarak kullanılabilir hale getirir
+// ("İzmir kamp alanları" -> "izmir-kamp-alanları")
+func QuerySlug(query string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(query)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
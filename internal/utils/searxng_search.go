@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SearXNGProvider kendi sunucumuzda çalışan bir SearXNG instance'ını kullanır.
+// Instance ayarlarında "json" formatının açık olması gerekir.
+type SearXNGProvider struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+type searxngResponse struct {
+	Results []struct {
+		Title   string `json:"title"`
+		URL     string `json:"url"`
+		Content string `json:"content"`
+	} `json:"results"`
+}
+
+func NewSearXNGProvider(baseURL string) *SearXNGProvider {
+	return &SearXNGProvider{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *SearXNGProvider) Search(ctx context.Context, query string) ([]SearchItem, error) {
+	params := url.Values{}
+	params.Add("q", query)
+	params.Add("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.BaseURL+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP isteği oluşturulurken hata oluştu: %w", err)
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP isteği gönderilirken hata oluştu: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Yanıt gövdesi okunurken hata oluştu: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SearXNG hatası: Durum kodu %d, Mesaj: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result searxngResponse
+	if err := json.Unmarshal(bodyBytes, &result); err != nil {
+		return nil, fmt.Errorf("JSON ayrıştırılırken hata oluştu: %w", err)
+	}
+
+	items := make([]SearchItem, 0, len(result.Results))
+	for _, r := range result.Results {
+		items = append(items, SearchItem{Title: r.Title, Link: r.URL, Snippet: r.Content})
+	}
+	return items, nil
+}
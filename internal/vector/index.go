@@ -0,0 +1,105 @@
+package vector
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// Index bellekte tutulan düz (brute-force) kosinüs benzerliği indeksi.
+// Katalog boyutunda (birkaç bin kayıt) tam tarama yeterince hızlıdır.
+type Index struct {
+	mu      sync.RWMutex
+	dim     int
+	ids     []string
+	vectors [][]float32
+	pos     map[string]int
+}
+
+// Match arama sonucu; Score kosinüs benzerliği (-1..1)
+type Match struct {
+	ID    string
+	Score float64
+}
+
+func NewIndex() *Index {
+	return &Index{pos: map[string]int{}}
+}
+
+// Add vektörü normalize ederek ekler, aynı ID varsa üzerine yazar.
+// Boyutu ilk vektörden farklı veya sıfır olan vektörler eklenmez.
+func (x *Index) Add(id string, v []float32) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if len(v) == 0 || (x.dim != 0 && len(v) != x.dim) {
+		return false
+	}
+	normalized := Normalize(v)
+	if normalized == nil {
+		return false
+	}
+	x.dim = len(v)
+	if i, ok := x.pos[id]; ok {
+		x.vectors[i] = normalized
+		return true
+	}
+	x.pos[id] = len(x.ids)
+	x.ids = append(x.ids, id)
+	x.vectors = append(x.vectors, normalized)
+	return true
+}
+
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.ids)
+}
+
+// Search sorguya en benzer k kaydı döndürür; allow nil değilse yalnızca izin verilen ID'ler aranır
+func (x *Index) Search(query []float32, k int, allow func(id string) bool) []Match {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	q := Normalize(query)
+	if q == nil || len(q) != x.dim {
+		return nil
+	}
+	matches := []Match{}
+	for i, id := range x.ids {
+		if allow != nil && !allow(id) {
+			continue
+		}
+		matches = append(matches, Match{ID: id, Score: dot(q, x.vectors[i])})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if k > 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches
+}
+
+// Normalize birim uzunlukta bir kopya döndürür, sıfır vektör için nil
+func Normalize(v []float32) []float32 {
+	var sum float64
+	for _, f := range v {
+		sum += float64(f) * float64(f)
+	}
+	if sum == 0 {
+		return nil
+	}
+	norm := math.Sqrt(sum)
+	out := make([]float32, len(v))
+	for i, f := range v {
+		out[i] = float32(float64(f) / norm)
+	}
+	return out
+}
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
@@ -0,0 +1,31 @@
+package vector
+
+import "testing"
+
+func TestIndexSearch(t *testing.T) {
+	index := NewIndex()
+	index.Add("lake", []float32{1, 0, 0})
+	index.Add("sea", []float32{0, 2, 0})
+	index.Add("forest", []float32{0.5, 0.5, 0})
+	if index.Add("bad", []float32{1, 0}) || index.Add("zero", []float32{0, 0, 0}) {
+		t.Error("mismatched or zero vector accepted")
+	}
+
+	matches := index.Search([]float32{3, 0.1, 0}, 2, nil)
+	if len(matches) != 2 || matches[0].ID != "lake" || matches[1].ID != "forest" {
+		t.Fatalf("matches = %+v, want lake, forest", matches)
+	}
+	if matches[0].Score < 0.99 {
+		t.Errorf("lake score = %f", matches[0].Score)
+	}
+
+	matches = index.Search([]float32{3, 0.1, 0}, 0, func(id string) bool { return id != "lake" })
+	if len(matches) != 2 || matches[0].ID != "forest" {
+		t.Errorf("filtered matches = %+v", matches)
+	}
+
+	index.Add("lake", []float32{0, 0, 1})
+	if index.Len() != 3 {
+		t.Errorf("Len = %d after overwrite, want 3", index.Len())
+	}
+}